@@ -28,6 +28,7 @@ func main() {
 		RoutingTable: services.NewRoutingTableService(&environment),
 		Device:       services.NewDeviceService(&environment, s),
 		Environment:  services.NewEnvironmentService(&environment),
+		Topology:     services.NewTopologyService(&environment),
 	}
 
 	apiController := controllers.NewApiController(services)
@@ -4,4 +4,5 @@ type ApiServices struct {
 	RoutingTable RoutingTableService
 	Device       DeviceService
 	Environment  EnvironmentService
+	Topology     TopologyService
 }
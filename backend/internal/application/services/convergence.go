@@ -0,0 +1,160 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// groundTruthLinks returns the links the routing tables should have, from
+// every device to the devices it is connected to that it reaches and that
+// reach it back. Links to devices that moved away are left out even while the
+// connection is kept.
+func groundTruthLinks(environment *entities.Environment) map[entities.Route]bool {
+	links := make(map[entities.Route]bool)
+
+	for label, device := range environment.GetDevices() {
+		devicesWithConn := device.GetDevicesWithConn()
+
+		for _, nearby := range environment.ScanDeviceNearby(label) {
+			if !environment.IsBidirectional(label, nearby.GetDeviceLabel()) {
+				continue
+			}
+			if _, connected := devicesWithConn[nearby.GetDeviceLabel()]; connected {
+				links[entities.Route{Source: label, Target: nearby.GetDeviceLabel()}] = true
+			}
+		}
+	}
+
+	return links
+}
+
+// reachableLinks returns the ground truth links every device can learn, the
+// ones of its connected component. A device can not learn the links of a part
+// of the network it has no path to.
+func reachableLinks(links map[entities.Route]bool) map[string]map[entities.Route]bool {
+	adjacent := make(map[string][]string)
+	for link := range links {
+		adjacent[link.Source] = append(adjacent[link.Source], link.Target)
+		adjacent[link.Target] = append(adjacent[link.Target], link.Source)
+	}
+
+	reachable := make(map[string]map[entities.Route]bool)
+	for start := range adjacent {
+		if _, seen := reachable[start]; seen {
+			continue
+		}
+
+		members := []string{start}
+		visited := map[string]bool{start: true}
+		for i := 0; i < len(members); i++ {
+			for _, next := range adjacent[members[i]] {
+				if !visited[next] {
+					visited[next] = true
+					members = append(members, next)
+				}
+			}
+		}
+
+		component := make(map[entities.Route]bool)
+		for link := range links {
+			if visited[link.Source] {
+				component[link] = true
+			}
+		}
+		for _, member := range members {
+			reachable[member] = component
+		}
+	}
+
+	return reachable
+}
+
+// deviceStaleness compares the routing table of device with the ground truth
+// links for every routing type. The links of its connected component are
+// missing when the table does not have them, the ones of the table that are
+// not in the ground truth anywhere are stale.
+func deviceStaleness(device *entities.Device, links, reachable map[entities.Route]bool) entities.DeviceStaleness {
+	staleness := entities.DeviceStaleness{Label: device.GetDeviceLabel()}
+	routingTable := device.CloneRoutingTable()
+
+	for _, routingType := range metrics.Names() {
+		for link := range reachable {
+			if _, known := routingTable[routingType][link.Source][link.Target]; !known {
+				staleness.Missing++
+			}
+		}
+
+		for source, targets := range routingTable[routingType] {
+			for target := range targets {
+				if !links[entities.Route{Source: source, Target: target}] {
+					staleness.Stale++
+				}
+			}
+		}
+	}
+
+	if total := len(reachable)*len(metrics.Names()) + staleness.Stale; total > 0 {
+		staleness.Score = float64(staleness.Missing+staleness.Stale) / float64(total)
+	}
+
+	return staleness
+}
+
+// checkConvergence compares every routing table with the ground truth links,
+// logs the converged event when the tables just converged and returns the
+// convergence status. It is called after every change of the links or the
+// tables.
+func checkConvergence(environment *entities.Environment) entities.ConvergenceStatus {
+	links := groundTruthLinks(environment)
+	devices := devicesStaleness(environment, links)
+
+	consistent := !slices.ContainsFunc(devices, func(staleness entities.DeviceStaleness) bool {
+		return staleness.Score > 0
+	})
+
+	now := time.Now()
+	if event, converged := environment.Convergence.Observe(links, consistent, now); converged {
+		logger.Info("Routing converged",
+			zap.String("journey", "Convergence"),
+			zap.Time("changedAt", event.ChangedAt),
+			zap.Duration("elapsed", event.Elapsed),
+		)
+	}
+
+	status := environment.Convergence.Status(now)
+	status.Devices = devices
+
+	return status
+}
+
+// convergenceStatus returns the convergence status as last recorded by
+// checkConvergence with the current staleness of every device, it records
+// nothing
+func convergenceStatus(environment *entities.Environment) entities.ConvergenceStatus {
+	status := environment.Convergence.Status(time.Now())
+	status.Devices = devicesStaleness(environment, groundTruthLinks(environment))
+
+	return status
+}
+
+// devicesStaleness compares the routing table of every device with the ground
+// truth links, sorted by device label
+func devicesStaleness(environment *entities.Environment, links map[entities.Route]bool) []entities.DeviceStaleness {
+	reachable := reachableLinks(links)
+
+	devices := make([]entities.DeviceStaleness, 0)
+	for label, device := range environment.GetDevices() {
+		devices = append(devices, deviceStaleness(device, links, reachable[label]))
+	}
+
+	slices.SortFunc(devices, func(a, b entities.DeviceStaleness) int {
+		return strings.Compare(a.Label, b.Label)
+	})
+
+	return devices
+}
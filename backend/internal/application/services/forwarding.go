@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// SendHopByHop sends a user message to the next hop the sender table has to
+// its target, the relays look up the following hops in their own tables
+func (rs deviceService) SendHopByHop(ctx context.Context, currentDevice *entities.Device, request entities.Request, policy entities.RoutingPolicy) error {
+	request.Header.RoutingType = policy.Metric
+	request.Header.HopLimit = entities.DefaultHopLimit
+
+	next, err := rs.nextHop(ctx, currentDevice, request.Header.Target, policy.Metric)
+	if err != nil && policy.FallbackMetric != "" {
+		logger.Info("Routing with the fallback metric",
+			zap.String("journey", "SendHopByHop"),
+			zap.String("metric", policy.Metric),
+			zap.String("fallbackMetric", policy.FallbackMetric),
+		)
+
+		request.Header.RoutingType = policy.FallbackMetric
+		next, err = rs.nextHop(ctx, currentDevice, request.Header.Target, policy.FallbackMetric)
+	}
+	if err != nil {
+		return err
+	}
+
+	rs.environment.Forwarding.Count(entities.ForwardingHopByHop, func(stats *entities.ForwardingStats) {
+		stats.Sent++
+	})
+
+	rs.forwardTo(currentDevice, next, request)
+
+	return nil
+}
+
+// RelayHopByHop forwards a hop-by-hop message to the next hop the current
+// device has to its target. Messages out of hops or with no route are
+// dropped.
+func (rs deviceService) RelayHopByHop(ctx context.Context, currentDevice *entities.Device, request entities.Request) error {
+	current := currentDevice.GetDeviceLabel()
+
+	if slices.Contains(request.Header.Trace, current) {
+		logger.Info("Message looped",
+			zap.String("journey", "RelayHopByHop"),
+			zap.String("current", current),
+			zap.Strings("trace", request.Header.Trace),
+		)
+
+		rs.environment.Forwarding.Count(entities.ForwardingHopByHop, func(stats *entities.ForwardingStats) {
+			stats.Loops++
+		})
+	}
+
+	if request.Header.HopLimit <= 0 {
+		logger.Info("Message dropped, out of hops",
+			zap.String("journey", "RelayHopByHop"),
+			zap.String("current", current),
+			zap.String("target", request.Header.Target),
+		)
+
+		rs.environment.Forwarding.Count(entities.ForwardingHopByHop, func(stats *entities.ForwardingStats) {
+			stats.Expired++
+		})
+
+		return fmt.Errorf("message to %s out of hops at %s", request.Header.Target, current)
+	}
+
+	next, err := rs.nextHop(ctx, currentDevice, request.Header.Target, request.Header.RoutingType)
+	if err != nil {
+		logger.Info("Message dropped, no route",
+			zap.String("journey", "RelayHopByHop"),
+			zap.String("current", current),
+			zap.String("target", request.Header.Target),
+		)
+
+		rs.environment.Forwarding.Count(entities.ForwardingHopByHop, func(stats *entities.ForwardingStats) {
+			stats.NoRoute++
+		})
+
+		return err
+	}
+
+	rs.forwardTo(currentDevice, next, request)
+
+	return nil
+}
+
+// nextHop returns the first hop of the path the device routing table has to
+// target
+func (rs deviceService) nextHop(ctx context.Context, device *entities.Device, target, routingType string) (string, error) {
+	routes, err := rs.GetRoute(ctx, device.GetDeviceLabel(), target, routingType, entities.RouteConstraints{})
+	if err != nil {
+		return "", err
+	}
+	if len(routes) == 0 {
+		return "", fmt.Errorf("no route to send message")
+	}
+
+	return routes[0].Target, nil
+}
+
+// forwardTo sends a hop-by-hop message from the current device to next, using
+// up one hop
+func (rs deviceService) forwardTo(currentDevice *entities.Device, next string, request entities.Request) {
+	nextDevice := rs.environment.GetDeviceByLabel(next)
+	if nextDevice == nil {
+		return
+	}
+
+	newRequest := request.RelayHop(currentDevice.GetDeviceLabel(), next)
+
+	rs.SendRequest(currentDevice, nextDevice, newRequest)
+}
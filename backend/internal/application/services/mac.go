@@ -0,0 +1,110 @@
+package services
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// transmitWithMAC queues request to be sent with CSMA/CA. The frames of a
+// device go through the MAC one at a time in the order they were sent, so
+// they never overlap each other on the air.
+func (rs deviceService) transmitWithMAC(currentDevice, targetDevice *entities.Device, request *entities.Request) {
+	frame := entities.MACFrame{
+		Source: currentDevice.GetDeviceLabel(),
+		Target: targetDevice.GetDeviceLabel(),
+		Topic:  request.Header.Topic,
+		Size:   request.Size(),
+	}
+
+	rs.environment.MAC.Enqueue(frame.Source, func() {
+		air := macAir{environment: rs.environment}
+		acknowledged := rs.environment.MAC.Send(frame, rs.environment.Medium.GetConfig(), air, func() {
+			targetDevice.AddRequestToReceived(request)
+		})
+		if acknowledged {
+			return
+		}
+
+		logger.Info("Frame not acknowledged",
+			zap.String("journey", "MAC"),
+			zap.String("source", frame.Source),
+			zap.String("target", frame.Target),
+			zap.String("topic", frame.Topic),
+		)
+	})
+}
+
+// macAir sends the frames of the MAC on the shared medium of the environment,
+// in real time
+type macAir struct {
+	environment *entities.Environment
+}
+
+// Backoff waits for the medium to be idle for a DIFS and then for a random
+// amount of idle slots in window, the countdown stops while the medium is
+// busy
+func (a macAir) Backoff(label string, window int) {
+	config := a.environment.MAC.GetConfig()
+
+	a.waitIdle(label, config)
+	for slots := rand.Intn(window + 1); slots > 0; {
+		time.Sleep(config.Slot)
+		if busy, _ := a.environment.SenseCarrier(label, time.Now()); busy {
+			a.waitIdle(label, config)
+			continue
+		}
+
+		slots--
+		a.environment.MAC.Count(label, func(stats *entities.MACStats) { stats.BackoffSlots++ })
+	}
+}
+
+// waitIdle returns once label found the medium idle for a DIFS
+func (a macAir) waitIdle(label string, config entities.MACConfig) {
+	for {
+		if busy, until := a.environment.SenseCarrier(label, time.Now()); busy {
+			a.environment.MAC.Count(label, func(stats *entities.MACStats) { stats.Deferrals++ })
+			time.Sleep(time.Until(until))
+			continue
+		}
+
+		time.Sleep(config.DIFS)
+		if busy, _ := a.environment.SenseCarrier(label, time.Now()); !busy {
+			return
+		}
+	}
+}
+
+func (a macAir) Wait(d time.Duration) {
+	time.Sleep(d)
+}
+
+// Send sends a frame of size bytes from source to target and returns how the
+// target got it
+func (a macAir) Send(source, target, topic string, size int) entities.Reception {
+	transmission := a.environment.Medium.Begin(source, target, topic, size, time.Now())
+	time.Sleep(time.Until(transmission.End))
+	return a.environment.Receive(transmission)
+}
+
+// Reserve keeps the devices that hear source off the medium for d, except
+// target which takes part in the exchange
+func (a macAir) Reserve(source, target string, d time.Duration) {
+	until := time.Now().Add(d)
+
+	// a snapshot of the devices, the frames are sent while devices are added
+	// and removed
+	devices := a.environment.GetDevices()
+	for label := range devices {
+		if label == source || label == target {
+			continue
+		}
+		if link, exists := a.environment.RadioLink(source, label); exists && link.InRange {
+			a.environment.MAC.Reserve(label, until)
+		}
+	}
+}
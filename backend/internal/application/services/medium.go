@@ -0,0 +1,51 @@
+package services
+
+import (
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// transmit puts request on the air to targetDevice. With the shared medium
+// off it gets there at once, otherwise it gets there when its last byte is
+// sent and only if the target could tell it from the frames sent meanwhile.
+// With the MAC on the frame waits in the queue of the sender, which contends
+// for the medium first.
+func (rs deviceService) transmit(currentDevice, targetDevice *entities.Device, request *entities.Request) {
+	if !rs.environment.Medium.GetConfig().Enabled {
+		targetDevice.AddRequestToReceived(request)
+		return
+	}
+	if rs.environment.MAC.GetConfig().Enabled {
+		rs.transmitWithMAC(currentDevice, targetDevice, request)
+		return
+	}
+
+	transmission := rs.environment.Medium.Begin(
+		currentDevice.GetDeviceLabel(),
+		targetDevice.GetDeviceLabel(),
+		request.Header.Topic,
+		request.Size(),
+		time.Now(),
+	)
+
+	time.AfterFunc(transmission.End.Sub(transmission.Start), func() {
+		reception := rs.environment.Receive(transmission)
+		if reception.Status != entities.ReceptionOK {
+			logger.Info("Frame lost",
+				zap.String("journey", "Medium"),
+				zap.String("source", transmission.Source),
+				zap.String("target", transmission.Target),
+				zap.String("topic", transmission.Topic),
+				zap.String("status", reception.Status),
+				zap.Float64("sinr", reception.SINR),
+				zap.Strings("interferers", reception.Interferers),
+			)
+			return
+		}
+
+		targetDevice.AddRequestToReceived(request)
+	})
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
+
+// metrics has every routing type the devices keep in their routing tables
+var metrics = newMetricRegistry()
+
+func newMetricRegistry() *entities.MetricRegistry {
+	registry := &entities.MetricRegistry{}
+
+	for _, metric := range []entities.Metric{
+		{
+			Name:         "distance",
+			Description:  "Distance between the devices in the chart",
+			Combine:      entities.CombineAdditive,
+			ContentTypes: []string{"text"},
+			Weight:       distanceWeight,
+			Heuristic:    distanceHeuristic,
+		},
+		{
+			Name:         "latency",
+			Description:  "Latency of the link",
+			Combine:      entities.CombineAdditive,
+			ContentTypes: []string{"audio"},
+			Weight: func(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+				return device.GetDevicesWithConn()[deviceConn].GetLatency(), nil
+			},
+		},
+		{
+			Name:         "error-rate",
+			Description:  "Expected transmissions from the error rate of the link",
+			Combine:      entities.CombineMultiplicative,
+			ContentTypes: []string{"file"},
+			Weight:       errorRateWeight,
+		},
+		{
+			Name:        "hop-count",
+			Description: "Every link counts as one hop",
+			Combine:     entities.CombineAdditive,
+			Weight: func(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+				return 1, nil
+			},
+		},
+		{
+			Name:        "etx",
+			Description: "Expected transmissions learned from the probes",
+			Combine:     entities.CombineAdditive,
+			Weight:      etxWeight,
+		},
+		{
+			Name:        capacityTopology,
+			Description: "Capacity of the link in Mbps",
+			Combine:     entities.CombineBottleneck,
+			Weight: func(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+				return device.GetDevicesWithConn()[deviceConn].GetCapacity(), nil
+			},
+		},
+	} {
+		if err := registry.Register(metric); err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
+
+func distanceWeight(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+	currDevice := device.GetDeviceLabel()
+	source := environment.GetDeviceInChart(currDevice)
+	target := environment.GetDeviceInChart(deviceConn)
+	if source == nil || target == nil {
+		return 0, fmt.Errorf("device not found in chart: %s -> %s", currDevice, deviceConn)
+	}
+
+	return environment.GetDistanceTo(source.X, source.Y, target.X, target.Y), nil
+}
+
+// distanceHeuristic estimates the distance between two devices with their
+// straight line distance in the chart. The links of a path are at least as
+// long as the straight line and each is at least 1 unit, so scaling it down by
+// 0.1% covers the up to 1 per arc lost when the weights are truncated. It only
+// holds while the table weights match the chart, a device that moved since the
+// last update can make the path found a bit longer than the shortest.
+func distanceHeuristic(environment *entities.Environment, source, target string) uint64 {
+	sourcePosition := environment.GetDeviceInChart(source)
+	targetPosition := environment.GetDeviceInChart(target)
+	if sourcePosition == nil || targetPosition == nil {
+		return 0
+	}
+
+	distance := environment.GetDistanceTo(sourcePosition.X, sourcePosition.Y, targetPosition.X, targetPosition.Y)
+
+	return uint64(distance * 999)
+}
+
+// errorRateWeight leaves out the links that lose every frame, out of range
+// links included, they would need infinite transmissions
+func errorRateWeight(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+	errorRate := device.GetDevicesWithConn()[deviceConn].GetErrorRate()
+	if errorRate >= 1 {
+		return 0, fmt.Errorf("link loses every frame: %s -> %s", device.GetDeviceLabel(), deviceConn)
+	}
+
+	return 1 / (1 - max(errorRate, 0)), nil
+}
+
+func etxWeight(environment *entities.Environment, device *entities.Device, deviceConn string) (float64, error) {
+	etx, ok := device.GetETX(deviceConn, time.Now())
+	if !ok {
+		return 0, fmt.Errorf("no etx estimate yet: %s -> %s", device.GetDeviceLabel(), deviceConn)
+	}
+
+	return etx, nil
+}
+
+// routingPolicy returns the policy that routes a message of contentType from
+// sender to a device of destinationGroup. Without a matching policy the
+// message is routed by the metric that claims the content type, and content
+// types no metric claims can not be routed.
+func routingPolicy(environment *entities.Environment, contentType string, sender *entities.Device, destinationGroup string) (entities.RoutingPolicy, error) {
+	if policy, exists := environment.Policies.Match(contentType, sender.GetGroup(), destinationGroup); exists {
+		return policy, nil
+	}
+
+	metric, exists := metrics.ForContentType(contentType)
+	if !exists {
+		return entities.RoutingPolicy{}, fmt.Errorf("no routing policy for content type: %s", contentType)
+	}
+
+	return withPolicyDefaults(entities.RoutingPolicy{
+		ContentType: contentType,
+		Metric:      metric.Name,
+	}), nil
+}
+
+// withPolicyDefaults fills the reliability mode and the priority class left
+// empty
+func withPolicyDefaults(policy entities.RoutingPolicy) entities.RoutingPolicy {
+	if policy.Reliability == "" {
+		policy.Reliability = entities.ReliabilityAcknowledged
+	}
+	if policy.Priority == "" {
+		policy.Priority = entities.PriorityNormal
+	}
+	return policy
+}
+
+// validatePolicy checks the policy and that its metrics are registered
+func validatePolicy(policy entities.RoutingPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	for _, name := range []string{policy.Metric, policy.FallbackMetric} {
+		if _, exists := metrics.Get(name); name != "" && !exists {
+			return fmt.Errorf("unknown routing type: %s", name)
+		}
+	}
+	return nil
+}
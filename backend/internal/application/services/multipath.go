@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/internal/domain/entities/dijkstra"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// forwardingRoutes picks the path of a user message with the multipath config
+// of the environment and counts the message on it
+func (rs deviceService) forwardingRoutes(ctx context.Context, request entities.Request, routingType string) ([]entities.Route, error) {
+	config := rs.environment.Multipath.GetConfig()
+
+	metric, exists := metrics.Get(routingType)
+	if !exists {
+		return nil, fmt.Errorf("unknown routing type: %s", routingType)
+	}
+
+	// the widest path has no notion of equal or close costs
+	if config.Mode == entities.MultipathSingle || metric.Combine == entities.CombineBottleneck {
+		routes, err := rs.GetRoute(ctx, request.Header.Sender, request.Header.Destination, routingType, entities.RouteConstraints{})
+		if err != nil {
+			return nil, err
+		}
+
+		rs.environment.Multipath.Record(routesPath(routes))
+		return routes, nil
+	}
+
+	sourceDevice := rs.environment.GetDeviceByLabel(request.Header.Sender)
+	if sourceDevice == nil {
+		return nil, fmt.Errorf("device not found: %s", request.Header.Sender)
+	}
+
+	graph := rs.routingGraph(sourceDevice, metric)
+
+	paths, weights, err := candidatePaths(graph, config, request.Header.Sender, request.Header.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	path := paths[pickPath(weights, pathSelector(config, request))]
+
+	logger.Info("Multipath route",
+		zap.String("journey", "SendUserMessage"),
+		zap.String("mode", config.Mode),
+		zap.Int("paths", len(paths)),
+		zap.Strings("path", path.Path),
+	)
+
+	rs.environment.Multipath.Record(path.Path)
+
+	routes := make([]entities.Route, 0, len(path.Path)-1)
+	for i := 0; i < len(path.Path)-1; i++ {
+		routes = append(routes, entities.Route{Source: path.Path[i], Target: path.Path[i+1]})
+	}
+
+	return routes, nil
+}
+
+// candidatePaths returns the paths a flow can be spread over with the weight
+// of each one. Equal-cost paths share the load evenly, unequal-cost paths get
+// a share inversely proportional to their distance.
+func candidatePaths(graph dijkstra.MappedGraph[string], config entities.MultipathConfig, source, target string) ([]dijkstra.BestPath[string], []float64, error) {
+	var paths []dijkstra.BestPath[string]
+
+	switch config.Mode {
+	case entities.MultipathECMP:
+		// every arc weighs at least 1, so no loop can tie with a path
+		all, err := graph.ShortestAll(source, target)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// sorted so a flow hashes to the same path every time
+		slices.SortFunc(all.Paths, slices.Compare)
+		for _, path := range all.Paths[:min(len(all.Paths), config.MaxPaths)] {
+			paths = append(paths, dijkstra.BestPath[string]{Distance: all.Distance, Path: path})
+		}
+	case entities.MultipathWeighted:
+		shortest, err := graph.KShortest(source, target, config.MaxPaths)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		limit := float64(shortest[0].Distance) * config.Variance
+		for _, path := range shortest {
+			if float64(path.Distance) <= limit {
+				paths = append(paths, path)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown multipath mode: %s", config.Mode)
+	}
+
+	weights := make([]float64, 0, len(paths))
+	for _, path := range paths {
+		weights = append(weights, 1/float64(max(path.Distance, 1)))
+	}
+
+	return paths, weights, nil
+}
+
+// pathSelector returns a number in [0, 1) that picks the path of the message.
+// It is the hash of the flow so its messages keep the same path, or random
+// when every message picks its own path.
+func pathSelector(config entities.MultipathConfig, request entities.Request) float64 {
+	if config.Balancing == entities.BalancePacket {
+		return rand.Float64()
+	}
+
+	hash := fnv.New64a()
+	for _, field := range []string{request.Header.Sender, request.Header.Destination, request.Header.ContentType} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+
+	return float64(hash.Sum64()>>11) / (1 << 53)
+}
+
+// pickPath returns the index of the path that selector falls on, every path
+// taking a share of [0, 1) proportional to its weight
+func pickPath(weights []float64, selector float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	cumulative := 0.0
+	for i, weight := range weights {
+		cumulative += weight / total
+		if selector < cumulative {
+			return i
+		}
+	}
+
+	return len(weights) - 1
+}
+
+// routesPath returns the devices a list of routes goes through
+func routesPath(routes []entities.Route) []string {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	path := []string{routes[0].Source}
+	for _, route := range routes {
+		path = append(path, route.Target)
+	}
+
+	return path
+}
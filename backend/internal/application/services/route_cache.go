@@ -0,0 +1,62 @@
+package services
+
+import (
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// invalidateBrokenLinks marks on every device the links of deviceLabel to a
+// device no longer nearby, or that can no longer reach it back, as broken and
+// drops the cached paths that use them. The routing tables take a while to
+// forget the links, the paths should not wait for them. The links to the
+// devices nearby that reach it back are unmarked.
+func invalidateBrokenLinks(environment *entities.Environment, deviceLabel string, previousConn map[string]entities.Connection, devicesNearby []*entities.Device) {
+	nearby := make(map[string]bool, len(devicesNearby))
+	restored := make([]entities.Route, 0)
+	for _, device := range devicesNearby {
+		label := device.GetDeviceLabel()
+		nearby[label] = environment.IsBidirectional(deviceLabel, label)
+		if nearby[label] {
+			restored = append(restored,
+				entities.Route{Source: deviceLabel, Target: label},
+				entities.Route{Source: label, Target: deviceLabel},
+			)
+		}
+	}
+
+	broken := make([]entities.Route, 0)
+	for deviceConn := range previousConn {
+		if !nearby[deviceConn] {
+			broken = append(broken,
+				entities.Route{Source: deviceLabel, Target: deviceConn},
+				entities.Route{Source: deviceConn, Target: deviceLabel},
+			)
+		}
+	}
+
+	for label, device := range environment.GetDevices() {
+		device.RestoreLinks(restored)
+		if dropped := device.BreakLinks(broken); dropped > 0 {
+			logger.Info("Cached routes invalidated",
+				zap.String("journey", "RouteCache"),
+				zap.String("deviceLabel", label),
+				zap.Int("dropped", dropped),
+			)
+		}
+	}
+}
+
+// invalidateCachedRoutes drops the cached paths of every device that use a
+// link broken tells is broken
+func invalidateCachedRoutes(environment *entities.Environment, broken func(link entities.Route) bool) {
+	for label, device := range environment.GetDevices() {
+		if dropped := device.InvalidateCachedRoutes(broken); dropped > 0 {
+			logger.Info("Cached routes invalidated",
+				zap.String("journey", "RouteCache"),
+				zap.String("deviceLabel", label),
+				zap.Int("dropped", dropped),
+			)
+		}
+	}
+}
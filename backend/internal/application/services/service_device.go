@@ -1,11 +1,14 @@
 package services
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/rand"
 	"runtime"
+	"slices"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
@@ -22,6 +25,8 @@ func NewDeviceService(environment *entities.Environment, scheduler gocron.Schedu
 		scheduler:   scheduler,
 		jobs: Jobs{
 			UpdateRoutingTable: make(map[string]context.CancelFunc),
+			SweepRoutingTable:  make(map[string]context.CancelFunc),
+			Probe:              make(map[string]context.CancelFunc),
 			Request:            make(map[string]context.CancelFunc),
 			Walk:               make(map[string]context.CancelFunc),
 		},
@@ -36,6 +41,8 @@ type deviceService struct {
 
 type Jobs struct {
 	UpdateRoutingTable map[string]context.CancelFunc
+	SweepRoutingTable  map[string]context.CancelFunc
+	Probe              map[string]context.CancelFunc
 	Request            map[string]context.CancelFunc
 	Walk               map[string]context.CancelFunc
 }
@@ -45,7 +52,7 @@ type DeviceService interface {
 	InsertDevice(ctx context.Context, device entities.Device) (entities.Device, error)
 	UpdateRoutingTable(ctx context.Context, deviceLabel string) error
 	GetDevice(ctx context.Context, deviceLabel string) (entities.Device, error)
-	GetRoute(tx context.Context, sourceId string, targetId string, routingType string) ([]entities.Route, error)
+	GetRoute(tx context.Context, sourceId string, targetId string, routingType string, constraints entities.RouteConstraints) ([]entities.Route, error)
 	DeleteDevice(ctx context.Context, deviceLabel string) error
 	SendUserMessage(ctx context.Context, request entities.Request) error
 }
@@ -72,11 +79,15 @@ func (rs deviceService) InsertDevice(ctx context.Context, device entities.Device
 	device.SetStatus(true)
 
 	rs.environment.AddDevice(&device)
+	syncTopology(rs.environment, &device)
+	checkConvergence(rs.environment)
 
 	label := device.GetDeviceLabel()
 
 	rs.ScheduleReadRequests(label)
 	rs.ScheduleUpdateRoutingTable(label)
+	rs.ScheduleSweepRoutingTable(label)
+	rs.ScheduleProbe(label)
 	// rs.ScheduleWalk(label)
 
 	rs.scheduler.Start()
@@ -110,24 +121,43 @@ func (rs deviceService) SendUserMessage(ctx context.Context, request entities.Re
 		return fmt.Errorf("device not found: %s", request.Header.Sender)
 	}
 
+	if request.Header.Destination == entities.BroadcastDestination {
+		return rs.SendBroadcastMessage(ctx, currentDevice, request)
+	}
+
 	targetDevice := rs.environment.GetDeviceByLabel(request.Header.Destination)
 	if targetDevice == nil {
 		return fmt.Errorf("device not found: %s", request.Header.Destination)
 	}
 
-	var routingType string
-	switch request.Header.ContentType {
-	case "text":
-		routingType = "distance"
-	case "audio":
-		routingType = "latency"
-	case "file":
-		routingType = "error-rate"
-	default:
-		routingType = "distance"
+	policy, err := routingPolicy(rs.environment, request.Header.ContentType, currentDevice, targetDevice.GetGroup())
+	if err != nil {
+		return err
 	}
 
-	routes, err := rs.GetRoute(ctx, request.Header.Sender, request.Header.Destination, routingType)
+	request.Header.Reliability = policy.Reliability
+	request.Header.Priority = policy.Priority
+	request.Header.Target = request.Header.Destination
+
+	request.Header.Forwarding, err = rs.environment.Forwarding.Resolve(request.Header.Forwarding)
+	if err != nil {
+		return err
+	}
+
+	if request.Header.Forwarding == entities.ForwardingHopByHop {
+		return rs.SendHopByHop(ctx, currentDevice, request, policy)
+	}
+
+	routes, err := rs.forwardingRoutes(ctx, request, policy.Metric)
+	if (err != nil || len(routes) == 0) && policy.FallbackMetric != "" {
+		logger.Info("Routing with the fallback metric",
+			zap.String("journey", "SendUserMessage"),
+			zap.String("metric", policy.Metric),
+			zap.String("fallbackMetric", policy.FallbackMetric),
+		)
+
+		routes, err = rs.forwardingRoutes(ctx, request, policy.FallbackMetric)
+	}
 	if err != nil {
 		return err
 	}
@@ -136,11 +166,91 @@ func (rs deviceService) SendUserMessage(ctx context.Context, request entities.Re
 		return fmt.Errorf("no route to send message")
 	}
 
+	rs.environment.Forwarding.Count(entities.ForwardingSource, func(stats *entities.ForwardingStats) {
+		stats.Sent++
+	})
+
 	rs.PropagateRequest(ctx, routes, request)
 
 	return nil
 }
 
+// SendBroadcastMessage floods the message over the spanning tree of the
+// sender routing table, or over the Steiner tree that joins the sender to the
+// members when the header has any
+func (rs deviceService) SendBroadcastMessage(ctx context.Context, currentDevice *entities.Device, request entities.Request) error {
+	logger.Info("Init SendBroadcastMessage service",
+		zap.String("journey", "SendBroadcastMessage"),
+		zap.String("current", request.Header.Sender),
+		zap.Strings("members", request.Header.Members),
+	)
+
+	policy, err := routingPolicy(rs.environment, request.Header.ContentType, currentDevice, "")
+	if err != nil {
+		return err
+	}
+
+	metric, exists := metrics.Get(policy.Metric)
+	if !exists {
+		return fmt.Errorf("unknown routing type: %s", policy.Metric)
+	}
+	if metric.Combine == entities.CombineBottleneck {
+		return fmt.Errorf("%w: %s", ErrBottleneckMetric, policy.Metric)
+	}
+
+	request.Header.Priority = policy.Priority
+
+	graph := rs.routingGraph(currentDevice, metric)
+
+	var terminals []string
+	if len(request.Header.Members) > 0 {
+		terminals = append([]string{request.Header.Sender}, request.Header.Members...)
+	}
+
+	backbone, err := computeBackbone(graph, metric, "prim", terminals)
+	if err != nil {
+		return err
+	}
+
+	if len(backbone.Neighbours(request.Header.Sender)) == 0 {
+		return fmt.Errorf("no route to send message")
+	}
+
+	rs.ForwardBroadcast(currentDevice, "", backbone.Links, request)
+
+	return nil
+}
+
+// ForwardBroadcast sends the message to the backbone neighbours of the current
+// device, except the one it was received from
+func (rs deviceService) ForwardBroadcast(currentDevice *entities.Device, from string, backbone []entities.Route, request entities.Request) {
+	current := currentDevice.GetDeviceLabel()
+
+	for _, neighbour := range (entities.Backbone{Links: backbone}).Neighbours(current) {
+		if neighbour == from {
+			continue
+		}
+
+		neighbourDevice := rs.environment.GetDeviceByLabel(neighbour)
+		if neighbourDevice == nil {
+			continue
+		}
+
+		newRequest := entities.NewRequest(
+			"broadcast-message",
+			current,
+			neighbour,
+			backbone,
+			request.Body,
+		)
+		newRequest.Header.ContentType = request.Header.ContentType
+		newRequest.Header.Members = request.Header.Members
+		newRequest.Header.Priority = request.Header.Priority
+
+		rs.SendRequest(currentDevice, neighbourDevice, newRequest)
+	}
+}
+
 func (rs deviceService) PropagateRequest(ctx context.Context, routes []entities.Route, request entities.Request) {
 	sender := routes[0].Source
 	target := routes[0].Target
@@ -150,13 +260,7 @@ func (rs deviceService) PropagateRequest(ctx context.Context, routes []entities.
 
 	routes = routes[1:]
 
-	newRequest := entities.NewRequest(
-		"user-message",
-		sender,
-		target,
-		routes,
-		request.Body,
-	)
+	newRequest := request.Relay(sender, target, routes)
 
 	rs.SendRequest(currentDevice, targetDevice, newRequest)
 
@@ -215,7 +319,8 @@ func (rs deviceService) ProcessAutomaticRequests(ctx context.Context, device *en
 
 	deviceLabel := device.GetDeviceLabel()
 
-	for id, request := range device.GetUnreadRequests() {
+	for _, request := range byPriority(device.GetUnreadRequests()) {
+		id := request.ID
 		switch request.Header.Topic {
 		case "new-connection":
 			rs.NewConnection(ctx, deviceLabel, request.Header.Sender)
@@ -223,19 +328,25 @@ func (rs deviceService) ProcessAutomaticRequests(ctx context.Context, device *en
 			device.DeleteRequest(id)
 
 		case "new-connection-ack":
-			rs.NewConnectionAck(ctx, deviceLabel, request.Header.Sender)
+			rs.NewConnectionAck(ctx, deviceLabel, request.Header.Sender, request.Body.(entities.LinkQuality))
 			request.Read()
 			device.DeleteRequest(id)
 		case "confirm-connection":
-			rs.ConfirmConnection(ctx, deviceLabel, request.Header.Sender)
+			rs.ConfirmConnection(ctx, deviceLabel, request.Header.Sender, request.Body.(entities.LinkQuality))
 			request.Read()
 			device.DeleteRequest(id)
 		case "update-routing":
 			rs.UpdateRouting(ctx, deviceLabel, request.Header.Sender, request.Body.(entities.Routing))
 			device.DeleteRequest(id)
+		case "probe":
+			rs.Probe(ctx, deviceLabel, request.Header.Sender, request.Body.(map[string]float64), request.Date)
+			device.DeleteRequest(id)
 		case "user-message":
 			rs.UserMessage(ctx, deviceLabel, request.Header.Sender, *request)
 			request.Read()
+		case "broadcast-message":
+			rs.BroadcastMessage(ctx, deviceLabel, request.Header.Sender, *request)
+			request.Read()
 		default:
 			continue
 		}
@@ -244,6 +355,21 @@ func (rs deviceService) ProcessAutomaticRequests(ctx context.Context, device *en
 	return nil
 }
 
+// byPriority orders the requests by the rank of their priority class, the
+// oldest first within a class
+func byPriority(requests map[uuid.UUID]*entities.Request) []*entities.Request {
+	ordered := slices.Collect(maps.Values(requests))
+	slices.SortFunc(ordered, func(a, b *entities.Request) int {
+		return cmp.Or(
+			cmp.Compare(entities.PriorityRank(b.Header.Priority), entities.PriorityRank(a.Header.Priority)),
+			a.Date.Compare(b.Date),
+		)
+	})
+	return ordered
+}
+
+// NewConnection answers the scan of sender. A device that can not reach
+// sender back keeps it as an inbound one-way link instead of answering.
 func (rs deviceService) NewConnection(ctx context.Context, current, sender string) error {
 	logger.Info("Init NewConnection service",
 		zap.String("journey", "NewConnection"),
@@ -256,9 +382,17 @@ func (rs deviceService) NewConnection(ctx context.Context, current, sender strin
 		return fmt.Errorf("device not found: %s", current)
 	}
 
+	heard := linkQuality(rs.environment, sender, current)
+
 	isNearby := rs.environment.CheckIfDeviceIsNearby(current, sender)
 	if !isNearby {
 		fmt.Println("device not nearby: ", sender)
+		currentDevice.SetOneWayLink(entities.OneWayLink{
+			Neighbour: sender,
+			Direction: entities.LinkInbound,
+			Quality:   heard,
+			Since:     time.Now(),
+		})
 		return nil
 	}
 
@@ -269,7 +403,7 @@ func (rs deviceService) NewConnection(ctx context.Context, current, sender strin
 		current,
 		sender,
 		nil,
-		nil,
+		heard,
 	)
 
 	rs.SendRequest(currentDevice, senderDevice, request)
@@ -277,7 +411,9 @@ func (rs deviceService) NewConnection(ctx context.Context, current, sender strin
 	return nil
 }
 
-func (rs deviceService) NewConnectionAck(ctx context.Context, current, sender string) error {
+// NewConnectionAck connects current to sender, the answer proves the link
+// works both ways. forward is how sender got the scan of current.
+func (rs deviceService) NewConnectionAck(ctx context.Context, current, sender string, forward entities.LinkQuality) error {
 	logger.Info("Init NewConnectionAck service",
 		zap.String("journey", "NewConnectionAck"),
 		zap.String("current", current),
@@ -293,36 +429,103 @@ func (rs deviceService) NewConnectionAck(ctx context.Context, current, sender st
 		return fmt.Errorf("device not found: %s", sender)
 	}
 
+	reverse := linkQuality(rs.environment, sender, current)
+
 	request := entities.NewRequest(
 		"confirm-connection",
 		current,
 		sender,
 		nil,
-		nil,
+		reverse,
 	)
 
 	rs.SendRequest(currentDevice, senderDevice, request)
 
-	currentDevice.SetDeviceWithConn(
-		sender,
-		rand.Float64() * 100,
-		rand.Float64() * 100,
-	)
+	currentDevice.SetDeviceWithConn(sender, entities.Connection{
+		ErrorRate: forward.ErrorRate,
+		Latency:   rand.Float64() * 100,
+		Capacity:  entities.LinkCapacity(forward, rs.environment.Medium.GetConfig().NoiseFloor),
+		Forward:   forward,
+		Reverse:   reverse,
+	})
 
 	return nil
 }
 
-func (rs deviceService) ConfirmConnection(ctx context.Context, current, sender string) error {
+// ConfirmConnection connects current to sender once sender got its answer.
+// forward is how sender got the answer of current.
+func (rs deviceService) ConfirmConnection(ctx context.Context, current, sender string, forward entities.LinkQuality) error {
 	logger.Info("Init ConfirmConnection service",
 		zap.String("journey", "ConfirmConnection"),
 		zap.String("current", current),
 		zap.String("sender", sender),
 	)
 
-	logger.Info("ConfirmConnection",
-		zap.String("current", current),
-		zap.String("sender", sender),
-	)
+	currentDevice := rs.environment.GetDeviceByLabel(current)
+	if currentDevice == nil {
+		return fmt.Errorf("device not found: %s", current)
+	}
+
+	currentDevice.SetDeviceWithConn(sender, entities.Connection{
+		ErrorRate: forward.ErrorRate,
+		Latency:   rand.Float64() * 100,
+		Capacity:  entities.LinkCapacity(forward, rs.environment.Medium.GetConfig().NoiseFloor),
+		Forward:   forward,
+		Reverse:   linkQuality(rs.environment, sender, current),
+	})
+
+	return nil
+}
+
+// linkQuality returns how target gets the signal of source
+func linkQuality(environment *entities.Environment, source, target string) entities.LinkQuality {
+	link, exists := environment.RadioLink(source, target)
+	if !exists || !link.InRange {
+		return entities.LinkQuality{ErrorRate: 1}
+	}
+	return entities.NewLinkQuality(link)
+}
+
+// SendProbes broadcasts a probe to the devices in the coverage area, with the
+// ratio of the probes received from each of them. Each probe is lost with the
+// delivery ratio of the link, so the ETX is learned from what gets through.
+func (rs deviceService) SendProbes(ctx context.Context, deviceLabel string) error {
+	currentDevice := rs.environment.GetDeviceByLabel(deviceLabel)
+	if currentDevice == nil {
+		return fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	ratios := currentDevice.GetProbeRatios(time.Now())
+
+	for _, device := range rs.environment.ScanDeviceNearby(deviceLabel) {
+		target := device.GetDeviceLabel()
+		if rand.Float64() >= rs.environment.DeliveryRatio(deviceLabel, target) {
+			continue
+		}
+
+		request := entities.NewRequest(
+			"probe",
+			deviceLabel,
+			target,
+			nil,
+			ratios,
+		)
+
+		rs.SendRequest(currentDevice, device, request)
+	}
+
+	return nil
+}
+
+// Probe records a probe from sender sent at sentAt, ratios has the ratio of
+// the probes sender received from each of its neighbours
+func (rs deviceService) Probe(ctx context.Context, current, sender string, ratios map[string]float64, sentAt time.Time) error {
+	currentDevice := rs.environment.GetDeviceByLabel(current)
+	if currentDevice == nil {
+		return fmt.Errorf("device not found: %s", current)
+	}
+
+	currentDevice.ReceiveProbe(sender, ratios[current], sentAt)
 
 	return nil
 }
@@ -338,10 +541,10 @@ func (rs deviceService) UpdateRouting(ctx context.Context, current, sender strin
 		return fmt.Errorf("device not found: %s", current)
 	}
 
-	currentDevice.RemoveFromTableRoutesWith(sender)
-	currentDevice.AddRouting(routingTable)
+	currentDevice.ReplaceRoutesWith(sender, routingTable.Relayed(sender, time.Now()))
 
 	currentDevice.PrintPrettyTable()
+	checkConvergence(rs.environment)
 
 	return nil
 }
@@ -364,15 +567,28 @@ func (rs deviceService) UserMessage(ctx context.Context, current, sender string,
 		return fmt.Errorf("device not found: %s", sender)
 	}
 
-	userMessageAck := entities.NewRequest(
-		"user-message-ack",
-		current,
-		sender,
-		nil,
-		nil,
-	)
+	if request.Header.Reliability != entities.ReliabilityBestEffort {
+		userMessageAck := entities.NewRequest(
+			"user-message-ack",
+			current,
+			sender,
+			nil,
+			nil,
+		)
+
+		rs.SendRequest(currentDevice, senderDevice, userMessageAck)
+	}
+
+	if request.Header.Target == current {
+		rs.environment.Forwarding.Count(request.Header.Forwarding, func(stats *entities.ForwardingStats) {
+			stats.Delivered++
+		})
+		return nil
+	}
 
-	rs.SendRequest(currentDevice, senderDevice, userMessageAck)
+	if request.Header.Forwarding == entities.ForwardingHopByHop {
+		return rs.RelayHopByHop(ctx, currentDevice, request)
+	}
 
 	if len(request.Header.Path) == 0 {
 		return nil
@@ -383,6 +599,24 @@ func (rs deviceService) UserMessage(ctx context.Context, current, sender string,
 	return nil
 }
 
+// BroadcastMessage keeps flooding a broadcast message over its backbone, no
+// ack is sent back so the sender is not flooded with answers
+func (rs deviceService) BroadcastMessage(ctx context.Context, current, sender string, request entities.Request) error {
+	logger.Info("Init BroadcastMessage service",
+		zap.String("journey", "BroadcastMessage"),
+		zap.String("current", current),
+	)
+
+	currentDevice := rs.environment.GetDeviceByLabel(current)
+	if currentDevice == nil {
+		return fmt.Errorf("device not found: %s", current)
+	}
+
+	rs.ForwardBroadcast(currentDevice, sender, request.Header.Path, request)
+
+	return nil
+}
+
 func (rs deviceService) UpdateRoutingTable(ctx context.Context, deviceLabel string) error {
 	logger.Info("Init UpdateRoutingTable service",
 		zap.String("journey", "UpdateRoutingTable"),
@@ -395,6 +629,7 @@ func (rs deviceService) UpdateRoutingTable(ctx context.Context, deviceLabel stri
 	}
 
 	currentDevice.SetScanningDevices(true)
+	previousConn := currentDevice.GetDevicesWithConn()
 	currentDevice.ResetDeviceConn()
 
 	devicePosition := rs.environment.GetDeviceInChart(deviceLabel)
@@ -403,12 +638,23 @@ func (rs deviceService) UpdateRoutingTable(ctx context.Context, deviceLabel stri
 	}
 
 	devicesNearby := rs.environment.ScanDeviceNearby(deviceLabel)
+	invalidateBrokenLinks(rs.environment, deviceLabel, previousConn, devicesNearby)
 	if len(devicesNearby) == 0 {
 		currentDevice.RemoveFromTableRoutesWith(deviceLabel)
+		syncTopology(rs.environment, currentDevice)
+		checkConvergence(rs.environment)
 		return nil
 	}
 
+	// the devices that do not answer in time stay as outbound one-way links
 	for _, device := range devicesNearby {
+		currentDevice.SetOneWayLink(entities.OneWayLink{
+			Neighbour: device.GetDeviceLabel(),
+			Direction: entities.LinkOutbound,
+			Quality:   linkQuality(rs.environment, deviceLabel, device.GetDeviceLabel()),
+			Since:     time.Now(),
+		})
+
 		request := entities.NewRequest(
 			"new-connection",
 			deviceLabel,
@@ -423,65 +669,88 @@ func (rs deviceService) UpdateRoutingTable(ctx context.Context, deviceLabel stri
 	time.Sleep(15 * time.Second)
 
 	rs.PropagateRoutingTable(ctx, currentDevice)
+	syncTopology(rs.environment, currentDevice)
+	checkConvergence(rs.environment)
 
 	currentDevice.SetScanningDevices(false)
 
 	return nil
 }
 
-func (rs deviceService) BuildRoutingTableRow(device *entities.Device, deviceConn string) entities.Routing {
-	currDevice := device.GetDeviceLabel()
+// SweepRoutingTable purges the expired entries of the routing table of the
+// device
+func (rs deviceService) SweepRoutingTable(ctx context.Context, deviceLabel string) error {
+	currentDevice := rs.environment.GetDeviceByLabel(deviceLabel)
+	if currentDevice == nil {
+		return fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	purged := currentDevice.PurgeExpiredRoutes(time.Now())
+	if purged == 0 {
+		return nil
+	}
 
-	weight := rs.environment.GetDistanceTo(
-		rs.environment.GetDeviceInChart(currDevice).X,
-		rs.environment.GetDeviceInChart(currDevice).Y,
-		rs.environment.GetDeviceInChart(deviceConn).X,
-		rs.environment.GetDeviceInChart(deviceConn).Y,
+	logger.Info("Expired routes purged",
+		zap.String("journey", "SweepRoutingTable"),
+		zap.String("deviceLabel", deviceLabel),
+		zap.Int("purged", purged),
 	)
 
+	checkConvergence(rs.environment)
+
+	return nil
+}
+
+func (rs deviceService) BuildRoutingTableRow(device *entities.Device, deviceConn string) entities.Routing {
+	currDevice := device.GetDeviceLabel()
+	now := time.Now()
+
 	routingTable := make(entities.Routing, 0)
-	
-	routingTable["distance"] = make(map[string]map[string]float64)
-	routingTable["distance"][currDevice] = make(map[string]float64)
-	routingTable["distance"][currDevice][deviceConn] = weight
 
-	routingTable["latency"] = make(map[string]map[string]float64)
-	routingTable["latency"][currDevice] = make(map[string]float64)
-	routingTable["latency"][currDevice][deviceConn] = device.DevicesWithConn[deviceConn].GetLatency()
+	for _, metric := range metrics.List() {
+		weight, err := metric.Weight(rs.environment, device, deviceConn)
+		if err != nil {
+			continue
+		}
 
-	routingTable["error-rate"] = make(map[string]map[string]float64)
-	routingTable["error-rate"][currDevice] = make(map[string]float64)
-	routingTable["error-rate"][currDevice][deviceConn] = 1 / (1 - device.DevicesWithConn[deviceConn].GetErrorRate())
+		routingTable.Set(metric.Name, currDevice, deviceConn, entities.NewOwnRouteEntry(currDevice, weight, now))
+	}
 
 	return routingTable
 }
 
 func (rs deviceService) PropagateRoutingTable(ctx context.Context, device *entities.Device) {
 	currDevice := device.GetDeviceLabel()
-	device.RemoveFromTableRoutesWith(currDevice)
 
+	neighbours := make([]*entities.Device, 0)
+	ownRoutes := make(entities.Routing)
 	for deviceConn := range device.GetDevicesWithConn() {
 		connDevice := rs.environment.GetDeviceByLabel(deviceConn)
 		if connDevice == nil {
 			continue
 		}
 
-		routingTable := rs.BuildRoutingTableRow(device, deviceConn)
-		device.AddRouting(routingTable)
+		neighbours = append(neighbours, connDevice)
+		ownRoutes.Merge(rs.BuildRoutingTableRow(device, deviceConn))
+	}
+
+	// the own links are replaced at once so the history gets a single version
+	device.ReplaceRoutesWith(currDevice, ownRoutes)
 
+	for _, connDevice := range neighbours {
 		request := entities.NewRequest(
 			"update-routing",
 			currDevice,
-			deviceConn,
+			connDevice.GetDeviceLabel(),
 			nil,
-			device.GetRoutingTable(),
+			device.CloneRoutingTable(),
 		)
 
 		rs.SendRequest(device, connDevice, request)
 	}
 }
 
-func (rs deviceService) GetRoute(tx context.Context, sourceId, targetId, routingType string) ([]entities.Route, error) {
+func (rs deviceService) GetRoute(tx context.Context, sourceId, targetId, routingType string, constraints entities.RouteConstraints) ([]entities.Route, error) {
 	logger.Info("Init GetRoute service",
 		zap.String("journey", "GetRoute"),
 	)
@@ -492,22 +761,49 @@ func (rs deviceService) GetRoute(tx context.Context, sourceId, targetId, routing
 		return nil, fmt.Errorf("device not found: %s", sourceId)
 	}
 
-	graph := dijkstra.NewMappedGraph[string]()
-	for device := range rs.environment.GetChart() {
-		graph.AddEmptyVertex(device)
+	metric, exists := metrics.Get(routingType)
+	if !exists {
+		return nil, fmt.Errorf("unknown routing type: %s", routingType)
 	}
 
-	for sourceLabel, target := range sourceDevice.GetRoutingTable()[routingType] {
-		for targetLabel, weight := range target {
-			graph.AddArc(
-				sourceLabel,
-				targetLabel,
-				uint64(weight*1000),
-			)
+	// only the paths with no constraints are cached, the version is read
+	// before the table so a path found with a newer table is never kept as
+	// current
+	cacheable := constraints.IsZero()
+	tableVersion := sourceDevice.RoutingTableVersion()
+	if cacheable {
+		if routes, hit := sourceDevice.GetCachedRoute(targetId, routingType); hit {
+			return routes, nil
 		}
 	}
 
-	best, err := graph.Shortest(sourceId, targetId)
+	graph := rs.routingGraph(sourceDevice, metric)
+
+	var best dijkstra.BestPath[string]
+	var err error
+	switch {
+	case metric.Combine == entities.CombineBottleneck:
+		if !constraints.IsZero() {
+			return nil, fmt.Errorf("constraints are not supported by bottleneck metrics: %s", routingType)
+		}
+		best, err = graph.Widest(sourceId, targetId)
+	case !constraints.IsZero():
+		best, err = graph.ShortestConstrained(sourceId, targetId, toDijkstraConstraints(metric, constraints))
+	case metric.Heuristic != nil:
+		var stats dijkstra.SearchStats
+		best, stats, err = graph.ShortestSearch(sourceId, targetId, dijkstra.SearchOptions[string]{
+			Algorithm: dijkstra.SearchAStar,
+			Heuristic: func(item, dest string) uint64 {
+				return metric.Heuristic(rs.environment, item, dest)
+			},
+		})
+		logger.Info("GetRoute search",
+			zap.String("journey", "GetRoute"),
+			zap.Int("expanded", stats.Expanded),
+		)
+	default:
+		best, err = graph.Shortest(sourceId, targetId)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -518,11 +814,46 @@ func (rs deviceService) GetRoute(tx context.Context, sourceId, targetId, routing
 		routes = append(routes, route)
 	}
 
+	if cacheable {
+		sourceDevice.CacheRoute(targetId, routingType, tableVersion, routes)
+	}
+
 	printAlloc()
 
 	return routes, nil
 }
 
+// routingGraph builds the graph of the links known by the routing table of the
+// device for the metric, between every device of the chart
+func (rs deviceService) routingGraph(device *entities.Device, metric entities.Metric) dijkstra.MappedGraph[string] {
+	labels := make([]string, 0)
+	for label := range rs.environment.GetChart() {
+		labels = append(labels, label)
+	}
+
+	return device.RoutingGraph(metric, labels)
+}
+
+func toDijkstraConstraints(metric entities.Metric, constraints entities.RouteConstraints) dijkstra.Constraints[string] {
+	excludeArcs := make([]dijkstra.Arc[string], 0, len(constraints.AvoidLinks))
+	for _, link := range constraints.AvoidLinks {
+		excludeArcs = append(excludeArcs, dijkstra.Arc[string]{Src: link.Source, Dest: link.Target})
+	}
+
+	var maxCost uint64
+	if constraints.MaxCost > 0 {
+		maxCost = metric.ArcWeight(constraints.MaxCost)
+	}
+
+	return dijkstra.Constraints[string]{
+		ExcludeVertices: constraints.Avoid,
+		ExcludeArcs:     excludeArcs,
+		Waypoints:       constraints.Via,
+		MaxHops:         constraints.MaxHops,
+		MaxCost:         maxCost,
+	}
+}
+
 func (rs deviceService) SendRequest(currentDevice *entities.Device, targetDevice *entities.Device, request entities.Request) {
 	logger.Info("Init SendRequest service",
 		zap.String("journey", "SendRequest"),
@@ -531,8 +862,8 @@ func (rs deviceService) SendRequest(currentDevice *entities.Device, targetDevice
 		zap.String("request", request.Header.Topic),
 	)
 
-	targetDevice.AddRequestToReceived(&request)
-	if request.Header.Topic == "user-message" || request.Header.Topic == "user-message-ack" {
+	rs.transmit(currentDevice, targetDevice, &request)
+	if request.Header.Topic == "user-message" || request.Header.Topic == "user-message-ack" || request.Header.Topic == "broadcast-message" {
 		currentDevice.AddRequestToSent(&request)
 	}
 	// currentDevice.AddRequestToSent(&request)
@@ -549,6 +880,10 @@ func (rs *deviceService) scheduleTask(deviceLabel string, interval time.Duration
 	switch jobType {
 	case "updateRoutingTable":
 		rs.jobs.UpdateRoutingTable[deviceLabel] = cancel
+	case "sweepRoutingTable":
+		rs.jobs.SweepRoutingTable[deviceLabel] = cancel
+	case "probe":
+		rs.jobs.Probe[deviceLabel] = cancel
 	case "request":
 		rs.jobs.Request[deviceLabel] = cancel
 	case "walk":
@@ -576,6 +911,11 @@ func (rs *deviceService) scheduleTask(deviceLabel string, interval time.Duration
 func (rs *deviceService) ScheduleWalk(deviceLabel string) {
 	rs.scheduleTask(deviceLabel, 35*time.Second, func() {
 		rs.environment.Walk(deviceLabel)
+
+		if device := rs.environment.GetDeviceByLabel(deviceLabel); device != nil {
+			syncMovedDevice(rs.environment, device)
+		}
+		checkConvergence(rs.environment)
 	}, "walk")
 }
 
@@ -585,6 +925,18 @@ func (rs *deviceService) ScheduleUpdateRoutingTable(deviceLabel string) {
 	}, "updateRoutingTable")
 }
 
+func (rs *deviceService) ScheduleSweepRoutingTable(deviceLabel string) {
+	rs.scheduleTask(deviceLabel, 10*time.Second, func() {
+		rs.SweepRoutingTable(context.Background(), deviceLabel)
+	}, "sweepRoutingTable")
+}
+
+func (rs *deviceService) ScheduleProbe(deviceLabel string) {
+	rs.scheduleTask(deviceLabel, entities.ProbeInterval, func() {
+		rs.SendProbes(context.Background(), deviceLabel)
+	}, "probe")
+}
+
 func (rs *deviceService) ScheduleReadRequests(deviceLabel string) {
 	rs.scheduleTask(deviceLabel, 5*time.Second, func() {
 		rs.ReadRequests(context.Background(), deviceLabel)
@@ -598,6 +950,16 @@ func (rs *deviceService) CancelJob(jobType, deviceLabel string) {
 			cancel()
 			delete(rs.jobs.UpdateRoutingTable, deviceLabel)
 		}
+	case "sweepRoutingTable":
+		if cancel, exists := rs.jobs.SweepRoutingTable[deviceLabel]; exists {
+			cancel()
+			delete(rs.jobs.SweepRoutingTable, deviceLabel)
+		}
+	case "probe":
+		if cancel, exists := rs.jobs.Probe[deviceLabel]; exists {
+			cancel()
+			delete(rs.jobs.Probe, deviceLabel)
+		}
 	case "request":
 		if cancel, exists := rs.jobs.Request[deviceLabel]; exists {
 			cancel()
@@ -625,8 +987,15 @@ func (rs deviceService) DeleteDevice(ctx context.Context, deviceLabel string) er
 	rs.CancelJob("request", deviceLabel)
 	rs.CancelJob("walk", deviceLabel)
 	rs.CancelJob("updateRoutingTable", deviceLabel)
+	rs.CancelJob("sweepRoutingTable", deviceLabel)
+	rs.CancelJob("probe", deviceLabel)
 
 	rs.environment.RemoveDevice(deviceLabel)
+	removeFromTopology(rs.environment, deviceLabel)
+	invalidateCachedRoutes(rs.environment, func(link entities.Route) bool {
+		return link.Source == deviceLabel || link.Target == deviceLabel
+	})
+	checkConvergence(rs.environment)
 
 	device.ResetDeviceConn()
 	device.ResetRoutingTable()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/luuisavelino/network-interface/internal/domain/entities"
 	"github.com/luuisavelino/network-interface/pkg/logger"
 	"go.uber.org/zap"
@@ -22,7 +23,26 @@ type environmentService struct {
 type EnvironmentService interface {
 	GetEnvironment(ctx context.Context) (entities.Environment, error)
 	GetChart(ctx context.Context) (entities.Chart, error)
+	GetChartLinks(ctx context.Context) ([]entities.DirectionalLink, error)
 	SetDeviceInChart(ctx context.Context, deviceLabel string, coverageArea entities.CoverageArea)
+	GetMetrics(ctx context.Context) ([]entities.Metric, error)
+	GetPolicies(ctx context.Context) ([]entities.RoutingPolicy, error)
+	InsertPolicy(ctx context.Context, policy entities.RoutingPolicy) (entities.RoutingPolicy, error)
+	UpdatePolicy(ctx context.Context, policy entities.RoutingPolicy) (entities.RoutingPolicy, error)
+	DeletePolicy(ctx context.Context, id uuid.UUID) error
+	GetMultipath(ctx context.Context) (entities.MultipathConfig, []entities.PathLoad, error)
+	SetMultipathConfig(ctx context.Context, config entities.MultipathConfig) error
+	ResetMultipathLoad(ctx context.Context) error
+	GetForwarding(ctx context.Context) (string, map[string]entities.ForwardingStats, error)
+	SetForwardingMode(ctx context.Context, mode string) error
+	GetPropagation(ctx context.Context) (entities.PropagationConfig, []entities.RadioLink, error)
+	SetPropagation(ctx context.Context, config entities.PropagationConfig) error
+	GetMedium(ctx context.Context) (entities.MediumConfig, map[string]entities.MediumStats, []entities.Reception, error)
+	SetMediumConfig(ctx context.Context, config entities.MediumConfig) error
+	ResetMediumStats(ctx context.Context) error
+	GetMAC(ctx context.Context) (entities.MACConfig, map[string]entities.MACStats, error)
+	SetMACConfig(ctx context.Context, config entities.MACConfig) error
+	ResetMACStats(ctx context.Context) error
 }
 
 func (rs environmentService) GetEnvironment(ctx context.Context) (entities.Environment, error) {
@@ -41,6 +61,16 @@ func (rs environmentService) GetChart(ctx context.Context) (entities.Chart, erro
 	return rs.environment.GetChart(), nil
 }
 
+// GetChartLinks returns the links between the devices in the chart, the
+// bidirectional ones and the ones only one side can use
+func (rs environmentService) GetChartLinks(ctx context.Context) ([]entities.DirectionalLink, error) {
+	logger.Info("Init GetChartLinks service",
+		zap.String("journey", "GetChartLinks"),
+	)
+
+	return rs.environment.DirectionalLinks(), nil
+}
+
 func (rs environmentService) SetDeviceInChart(ctx context.Context, deviceLabel string, coverageArea entities.CoverageArea) {
 	logger.Info("Init SetDeviceInChart service",
 		zap.String("journey", "SetDeviceInChart"),
@@ -55,7 +85,204 @@ func (rs environmentService) SetDeviceInChart(ctx context.Context, deviceLabel s
 		return
 	}
 
-	coverageArea.R = float64(device.Power)
+	coverageArea.R = rs.environment.CoverageRadius(device)
 
 	rs.environment.SetDeviceInChart(deviceLabel, coverageArea)
+	syncMovedDevice(rs.environment, device)
+	checkConvergence(rs.environment)
+}
+
+// GetMetrics returns the registered metrics, every one of them is a routing
+// type
+func (rs environmentService) GetMetrics(ctx context.Context) ([]entities.Metric, error) {
+	logger.Info("Init GetMetrics service",
+		zap.String("journey", "GetMetrics"),
+	)
+
+	return metrics.List(), nil
+}
+
+// GetPolicies returns the routing policies of the environment in the order
+// they were added
+func (rs environmentService) GetPolicies(ctx context.Context) ([]entities.RoutingPolicy, error) {
+	logger.Info("Init GetPolicies service",
+		zap.String("journey", "GetPolicies"),
+	)
+
+	return rs.environment.Policies.List(), nil
+}
+
+func (rs environmentService) InsertPolicy(ctx context.Context, policy entities.RoutingPolicy) (entities.RoutingPolicy, error) {
+	logger.Info("Init InsertPolicy service",
+		zap.String("journey", "InsertPolicy"),
+		zap.String("contentType", policy.ContentType),
+		zap.String("metric", policy.Metric),
+	)
+
+	policy = withPolicyDefaults(policy)
+	if err := validatePolicy(policy); err != nil {
+		return entities.RoutingPolicy{}, err
+	}
+
+	return rs.environment.Policies.Add(policy), nil
+}
+
+func (rs environmentService) UpdatePolicy(ctx context.Context, policy entities.RoutingPolicy) (entities.RoutingPolicy, error) {
+	logger.Info("Init UpdatePolicy service",
+		zap.String("journey", "UpdatePolicy"),
+		zap.String("id", policy.ID.String()),
+	)
+
+	policy = withPolicyDefaults(policy)
+	if err := validatePolicy(policy); err != nil {
+		return entities.RoutingPolicy{}, err
+	}
+
+	if err := rs.environment.Policies.Update(policy); err != nil {
+		return entities.RoutingPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+func (rs environmentService) DeletePolicy(ctx context.Context, id uuid.UUID) error {
+	logger.Info("Init DeletePolicy service",
+		zap.String("journey", "DeletePolicy"),
+		zap.String("id", id.String()),
+	)
+
+	return rs.environment.Policies.Remove(id)
+}
+
+// GetMultipath returns the multipath config and the messages sent over every
+// path
+func (rs environmentService) GetMultipath(ctx context.Context) (entities.MultipathConfig, []entities.PathLoad, error) {
+	logger.Info("Init GetMultipath service",
+		zap.String("journey", "GetMultipath"),
+	)
+
+	return rs.environment.Multipath.GetConfig(), rs.environment.Multipath.GetLoad(), nil
+}
+
+func (rs environmentService) SetMultipathConfig(ctx context.Context, config entities.MultipathConfig) error {
+	logger.Info("Init SetMultipathConfig service",
+		zap.String("journey", "SetMultipathConfig"),
+		zap.String("mode", config.Mode),
+		zap.String("balancing", config.Balancing),
+	)
+
+	return rs.environment.Multipath.SetConfig(config)
+}
+
+func (rs environmentService) ResetMultipathLoad(ctx context.Context) error {
+	logger.Info("Init ResetMultipathLoad service",
+		zap.String("journey", "ResetMultipathLoad"),
+	)
+
+	rs.environment.Multipath.ResetLoad()
+
+	return nil
+}
+
+// GetForwarding returns the default forwarding mode and the counters of the
+// user messages of every mode
+func (rs environmentService) GetForwarding(ctx context.Context) (string, map[string]entities.ForwardingStats, error) {
+	logger.Info("Init GetForwarding service",
+		zap.String("journey", "GetForwarding"),
+	)
+
+	return rs.environment.Forwarding.GetMode(), rs.environment.Forwarding.GetStats(), nil
+}
+
+func (rs environmentService) SetForwardingMode(ctx context.Context, mode string) error {
+	logger.Info("Init SetForwardingMode service",
+		zap.String("journey", "SetForwardingMode"),
+		zap.String("mode", mode),
+	)
+
+	return rs.environment.Forwarding.SetMode(mode)
+}
+
+// GetPropagation returns the propagation model and the links between the
+// devices that hear each other
+func (rs environmentService) GetPropagation(ctx context.Context) (entities.PropagationConfig, []entities.RadioLink, error) {
+	logger.Info("Init GetPropagation service",
+		zap.String("journey", "GetPropagation"),
+	)
+
+	return rs.environment.GetPropagation(), rs.environment.RadioLinks(), nil
+}
+
+func (rs environmentService) SetPropagation(ctx context.Context, config entities.PropagationConfig) error {
+	logger.Info("Init SetPropagation service",
+		zap.String("journey", "SetPropagation"),
+		zap.String("model", config.Model),
+	)
+
+	return rs.environment.SetPropagation(config)
+}
+
+// GetMedium returns the shared medium config, the frame counters of every
+// device and the last frames lost to other frames
+func (rs environmentService) GetMedium(ctx context.Context) (entities.MediumConfig, map[string]entities.MediumStats, []entities.Reception, error) {
+	logger.Info("Init GetMedium service",
+		zap.String("journey", "GetMedium"),
+	)
+
+	stats, collisions := rs.environment.Medium.GetStats()
+	return rs.environment.Medium.GetConfig(), stats, collisions, nil
+}
+
+func (rs environmentService) SetMediumConfig(ctx context.Context, config entities.MediumConfig) error {
+	logger.Info("Init SetMediumConfig service",
+		zap.String("journey", "SetMediumConfig"),
+		zap.Bool("enabled", config.Enabled),
+		zap.Float64("dataRate", config.DataRate),
+	)
+
+	return rs.environment.Medium.SetConfig(config)
+}
+
+func (rs environmentService) ResetMediumStats(ctx context.Context) error {
+	logger.Info("Init ResetMediumStats service",
+		zap.String("journey", "ResetMediumStats"),
+	)
+
+	rs.environment.Medium.ResetStats()
+
+	return nil
+}
+
+// GetMAC returns the MAC config and the counters of every device
+func (rs environmentService) GetMAC(ctx context.Context) (entities.MACConfig, map[string]entities.MACStats, error) {
+	logger.Info("Init GetMAC service",
+		zap.String("journey", "GetMAC"),
+	)
+
+	return rs.environment.MAC.GetConfig(), rs.environment.MAC.GetStats(), nil
+}
+
+// SetMACConfig replaces the MAC config, the MAC contends for the shared medium
+// so it can only be on with it
+func (rs environmentService) SetMACConfig(ctx context.Context, config entities.MACConfig) error {
+	logger.Info("Init SetMACConfig service",
+		zap.String("journey", "SetMACConfig"),
+		zap.Bool("enabled", config.Enabled),
+	)
+
+	if config.Enabled && !rs.environment.Medium.GetConfig().Enabled {
+		return errors.New("the mac needs the shared medium enabled")
+	}
+
+	return rs.environment.MAC.SetConfig(config)
+}
+
+func (rs environmentService) ResetMACStats(ctx context.Context) error {
+	logger.Info("Init ResetMACStats service",
+		zap.String("journey", "ResetMACStats"),
+	)
+
+	rs.environment.MAC.ResetStats()
+
+	return nil
 }
@@ -1,7 +1,12 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/luuisavelino/network-interface/internal/domain/entities"
 	"github.com/luuisavelino/network-interface/pkg/logger"
@@ -19,13 +24,243 @@ type routingTableService struct {
 }
 
 type RoutingTableService interface {
-	GetTable(ctx context.Context) ([]entities.Routing, error)
+	GetTable(ctx context.Context, filter entities.RoutingTableFilter) (entities.RoutingTableView, error)
+	GetConvergence(ctx context.Context) (entities.ConvergenceStatus, error)
+	GetDeviceTable(ctx context.Context, deviceLabel string, at time.Time) (entities.RoutingVersion, error)
+	GetDeviceHistory(ctx context.Context, deviceLabel string) ([]entities.RoutingVersion, error)
+	GetDeviceTableDiff(ctx context.Context, deviceLabel string, from, to uint64) (entities.RoutingDiff, error)
+	GetRouteCache(ctx context.Context, deviceLabel string) (entities.RouteCacheStats, error)
 }
 
-func (rs routingTableService) GetTable(ctx context.Context) ([]entities.Routing, error) {
+// linkKey identifies a link of a routing type
+type linkKey struct {
+	routingType string
+	entities.Route
+}
+
+func (rs routingTableService) GetTable(ctx context.Context, filter entities.RoutingTableFilter) (entities.RoutingTableView, error) {
 	logger.Info("Init GetTable service",
 		zap.String("journey", "GetTable"),
+		zap.String("device", filter.Device),
+		zap.String("routingType", filter.Type),
+		zap.String("source", filter.Source),
+		zap.String("target", filter.Target),
 	)
 
-	return nil, nil
+	if filter.Type != "" && !slices.Contains(metrics.Names(), filter.Type) {
+		return entities.RoutingTableView{}, fmt.Errorf("unknown routing type: %s", filter.Type)
+	}
+
+	// a snapshot of the devices, devices added or removed meanwhile do not
+	// change the view
+	devices := rs.environment.GetDevices()
+
+	labels := make([]string, 0, len(devices))
+	if filter.Device != "" {
+		if devices[filter.Device] == nil {
+			return entities.RoutingTableView{}, fmt.Errorf("device not found: %s", filter.Device)
+		}
+		labels = append(labels, filter.Device)
+	} else {
+		for label := range devices {
+			labels = append(labels, label)
+		}
+		slices.Sort(labels)
+	}
+
+	knowledge := make(map[linkKey]map[string]float64)
+
+	// the live links are known by nobody until a table has them, so links
+	// every table misses show up too
+	for source, device := range devices {
+		for target := range device.GetDevicesWithConn() {
+			for _, routingType := range metrics.Names() {
+				if filter.Matches(routingType, source, target) {
+					knowledge[linkKey{routingType, entities.Route{Source: source, Target: target}}] = make(map[string]float64)
+				}
+			}
+		}
+	}
+
+	for _, label := range labels {
+		for routingType, sources := range devices[label].CloneRoutingTable() {
+			for source, targets := range sources {
+				for target, entry := range targets {
+					if !filter.Matches(routingType, source, target) {
+						continue
+					}
+
+					key := linkKey{routingType, entities.Route{Source: source, Target: target}}
+					if knowledge[key] == nil {
+						knowledge[key] = make(map[string]float64)
+					}
+					knowledge[key][label] = entry.Weight
+				}
+			}
+		}
+	}
+
+	view := entities.RoutingTableView{
+		Devices: labels,
+		Links:   make([]entities.LinkKnowledge, 0, len(knowledge)),
+	}
+
+	for key, weights := range knowledge {
+		link := entities.LinkKnowledge{
+			Route:      key.Route,
+			Type:       key.routingType,
+			Weights:    weights,
+			Missing:    make([]string, 0),
+			Consistent: true,
+		}
+
+		if source := devices[key.Source]; source != nil {
+			_, link.Live = source.GetDevicesWithConn()[key.Target]
+		}
+
+		for _, label := range labels {
+			if _, known := weights[label]; !known {
+				link.Missing = append(link.Missing, label)
+			}
+		}
+
+		known := slices.Collect(maps.Values(weights))
+		for _, weight := range known {
+			if weight != known[0] {
+				link.Consistent = false
+			}
+		}
+
+		if filter.Issues && !link.HasIssues() {
+			continue
+		}
+
+		view.Links = append(view.Links, link)
+	}
+
+	slices.SortFunc(view.Links, func(a, b entities.LinkKnowledge) int {
+		return cmp.Or(
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+		)
+	})
+
+	return view, nil
+}
+
+func (rs routingTableService) GetConvergence(ctx context.Context) (entities.ConvergenceStatus, error) {
+	logger.Info("Init GetConvergence service",
+		zap.String("journey", "GetConvergence"),
+	)
+
+	return convergenceStatus(rs.environment), nil
+}
+
+// GetDeviceTable returns the version of the routing table of the device that
+// was current at the given time, the latest one when at is zero
+func (rs routingTableService) GetDeviceTable(ctx context.Context, deviceLabel string, at time.Time) (entities.RoutingVersion, error) {
+	logger.Info("Init GetDeviceTable service",
+		zap.String("journey", "GetDeviceTable"),
+		zap.String("deviceLabel", deviceLabel),
+		zap.Time("at", at),
+	)
+
+	device := rs.environment.GetDeviceByLabel(deviceLabel)
+	if device == nil {
+		return entities.RoutingVersion{}, fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	if at.IsZero() {
+		if latest, found := device.GetLatestRoutingVersion(); found {
+			return latest, nil
+		}
+
+		return entities.RoutingVersion{Table: device.CloneRoutingTable()}, nil
+	}
+
+	routingVersion, found := device.GetRoutingAt(at)
+	if !found {
+		return entities.RoutingVersion{}, fmt.Errorf("no routing table of %s kept at %s", deviceLabel, at.Format(time.RFC3339))
+	}
+
+	return routingVersion, nil
+}
+
+func (rs routingTableService) GetDeviceHistory(ctx context.Context, deviceLabel string) ([]entities.RoutingVersion, error) {
+	logger.Info("Init GetDeviceHistory service",
+		zap.String("journey", "GetDeviceHistory"),
+		zap.String("deviceLabel", deviceLabel),
+	)
+
+	device := rs.environment.GetDeviceByLabel(deviceLabel)
+	if device == nil {
+		return nil, fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	return device.GetRoutingHistory(), nil
+}
+
+// GetDeviceTableDiff compares two versions of the routing table of the device,
+// to defaults to the latest version and from to the one before to
+func (rs routingTableService) GetDeviceTableDiff(ctx context.Context, deviceLabel string, from, to uint64) (entities.RoutingDiff, error) {
+	logger.Info("Init GetDeviceTableDiff service",
+		zap.String("journey", "GetDeviceTableDiff"),
+		zap.String("deviceLabel", deviceLabel),
+		zap.Uint64("from", from),
+		zap.Uint64("to", to),
+	)
+
+	device := rs.environment.GetDeviceByLabel(deviceLabel)
+	if device == nil {
+		return entities.RoutingDiff{}, fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	if to == 0 {
+		latest, found := device.GetLatestRoutingVersion()
+		if !found {
+			return entities.RoutingDiff{}, fmt.Errorf("no routing table version of %s kept", deviceLabel)
+		}
+		to = latest.Version
+	}
+
+	if from == 0 && to > 1 {
+		from = to - 1
+	}
+
+	toVersion, found := device.GetRoutingVersion(to)
+	if !found {
+		return entities.RoutingDiff{}, fmt.Errorf("routing table version %d of %s is not kept", to, deviceLabel)
+	}
+
+	// version 0 is the empty table the device starts with
+	var fromVersion entities.RoutingVersion
+	if from != 0 {
+		fromVersion, found = device.GetRoutingVersion(from)
+		if !found {
+			return entities.RoutingDiff{}, fmt.Errorf("routing table version %d of %s is not kept", from, deviceLabel)
+		}
+	}
+
+	diff := entities.DiffRouting(fromVersion.Table, toVersion.Table)
+	diff.From = from
+	diff.To = to
+
+	return diff, nil
+}
+
+// GetRouteCache returns the hit and miss counters and the paths cached by the
+// device
+func (rs routingTableService) GetRouteCache(ctx context.Context, deviceLabel string) (entities.RouteCacheStats, error) {
+	logger.Info("Init GetRouteCache service",
+		zap.String("journey", "GetRouteCache"),
+		zap.String("deviceLabel", deviceLabel),
+	)
+
+	device := rs.environment.GetDeviceByLabel(deviceLabel)
+	if device == nil {
+		return entities.RouteCacheStats{}, fmt.Errorf("device not found: %s", deviceLabel)
+	}
+
+	return device.GetRouteCacheStats(), nil
 }
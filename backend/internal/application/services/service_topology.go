@@ -0,0 +1,265 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/internal/domain/entities/dijkstra"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func NewTopologyService(environment *entities.Environment) TopologyService {
+	return topologyService{
+		environment: environment,
+	}
+}
+
+type topologyService struct {
+	environment *entities.Environment
+}
+
+type TopologyService interface {
+	GetDistanceMatrix(ctx context.Context, routingType string) (entities.DistanceMatrix, error)
+	GetTopologyAnalysis(ctx context.Context, routingType string) (entities.TopologyAnalysis, error)
+	GetCentrality(ctx context.Context, routingType string) ([]entities.DeviceCentrality, uint64, error)
+	ExportTopology(ctx context.Context, routingType, format string, w io.Writer) (uint64, error)
+	GetBackbone(ctx context.Context, routingType, algorithm string, terminals []string) (entities.Backbone, error)
+	GetMaxFlow(ctx context.Context, sources, sinks []string) (entities.MaxFlow, error)
+}
+
+func (rs topologyService) GetDistanceMatrix(ctx context.Context, routingType string) (entities.DistanceMatrix, error) {
+	logger.Info("Init GetDistanceMatrix service",
+		zap.String("journey", "GetDistanceMatrix"),
+		zap.String("routingType", routingType),
+	)
+
+	snapshot, metric, err := pathTopologySnapshot(rs.environment, routingType)
+	if err != nil {
+		return entities.DistanceMatrix{}, err
+	}
+
+	graph := snapshot.Graph()
+	allPairs := graph.AllPairsShortest()
+
+	matrix := entities.DistanceMatrix{
+		Devices:   allPairs.Vertices,
+		Distances: make([][]*float64, len(allPairs.Vertices)),
+		NextHops:  make([][]*string, len(allPairs.Vertices)),
+		Version:   snapshot.Version,
+	}
+
+	for i := range allPairs.Vertices {
+		matrix.Distances[i] = make([]*float64, len(allPairs.Vertices))
+		matrix.NextHops[i] = make([]*string, len(allPairs.Vertices))
+
+		for j := range allPairs.Vertices {
+			if distance := allPairs.Distances[i][j]; distance != dijkstra.Infinity {
+				value := metric.PathValue(distance)
+				matrix.Distances[i][j] = &value
+			}
+
+			if hop := allPairs.NextHops[i][j]; hop != -1 {
+				matrix.NextHops[i][j] = &allPairs.Vertices[hop]
+			}
+		}
+	}
+
+	return matrix, nil
+}
+
+func (rs topologyService) GetTopologyAnalysis(ctx context.Context, routingType string) (entities.TopologyAnalysis, error) {
+	logger.Info("Init GetTopologyAnalysis service",
+		zap.String("journey", "GetTopologyAnalysis"),
+		zap.String("routingType", routingType),
+	)
+
+	snapshot, metric, err := pathTopologySnapshot(rs.environment, routingType)
+	if err != nil {
+		return entities.TopologyAnalysis{}, err
+	}
+
+	graph := snapshot.Graph()
+
+	analysis := entities.TopologyAnalysis{
+		StronglyConnectedComponents: graph.StronglyConnectedComponents(),
+		WeaklyConnectedComponents:   graph.WeaklyConnectedComponents(),
+		ArticulationPoints:          graph.ArticulationPoints(),
+		Bridges:                     make([]entities.Route, 0),
+		Eccentricity:                make(map[string]float64),
+		Version:                     snapshot.Version,
+	}
+
+	analysis.Partitioned = len(analysis.WeaklyConnectedComponents) > 1
+	analysis.StronglyConnected = len(analysis.StronglyConnectedComponents) <= 1
+
+	for _, bridge := range graph.Bridges() {
+		analysis.Bridges = append(analysis.Bridges, entities.Route{Source: bridge.Src, Target: bridge.Dest})
+	}
+
+	// the eccentricity of every device is computed once, the radius and the
+	// diameter come from it
+	eccentricities, err := graph.Eccentricities()
+	for label, eccentricity := range eccentricities {
+		analysis.Eccentricity[label] = metric.PathValue(eccentricity)
+	}
+
+	if err == nil {
+		radius, diameter := dijkstra.EccentricityBounds(eccentricities)
+		radiusWeight := metric.PathValue(radius)
+		diameterWeight := metric.PathValue(diameter)
+		analysis.Radius = &radiusWeight
+		analysis.Diameter = &diameterWeight
+	}
+
+	return analysis, nil
+}
+
+func (rs topologyService) GetCentrality(ctx context.Context, routingType string) ([]entities.DeviceCentrality, uint64, error) {
+	logger.Info("Init GetCentrality service",
+		zap.String("journey", "GetCentrality"),
+		zap.String("routingType", routingType),
+	)
+
+	snapshot, metric, err := pathTopologySnapshot(rs.environment, routingType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	graph := snapshot.Graph()
+
+	betweenness := graph.BetweennessCentrality()
+	closeness := graph.ClosenessCentrality()
+	degree := graph.DegreeCentrality()
+
+	ranking := make([]entities.DeviceCentrality, 0, len(betweenness))
+	for label := range betweenness {
+		ranking = append(ranking, entities.DeviceCentrality{
+			Label:       label,
+			Betweenness: betweenness[label],
+			Closeness:   closeness[label] * metric.ArcScale(),
+			Degree:      degree[label],
+		})
+	}
+
+	slices.SortFunc(ranking, func(a, b entities.DeviceCentrality) int {
+		return cmp.Or(
+			cmp.Compare(b.Betweenness, a.Betweenness),
+			cmp.Compare(b.Closeness, a.Closeness),
+			cmp.Compare(a.Label, b.Label),
+		)
+	})
+
+	return ranking, snapshot.Version, nil
+}
+
+func (rs topologyService) ExportTopology(ctx context.Context, routingType, format string, w io.Writer) (uint64, error) {
+	logger.Info("Init ExportTopology service",
+		zap.String("journey", "ExportTopology"),
+		zap.String("routingType", routingType),
+		zap.String("format", format),
+	)
+
+	snapshot, err := topologySnapshot(rs.environment, routingType)
+	if err != nil {
+		return 0, err
+	}
+
+	graph := snapshot.Graph()
+
+	switch format {
+	case "dot":
+		err = graph.WriteDOT(w)
+	case "graphml":
+		err = graph.WriteGraphML(w)
+	case "json":
+		err = graph.WriteJSON(w)
+	default:
+		err = fmt.Errorf("unknown export format: %s", format)
+	}
+
+	return snapshot.Version, err
+}
+
+func (rs topologyService) GetBackbone(ctx context.Context, routingType, algorithm string, terminals []string) (entities.Backbone, error) {
+	logger.Info("Init GetBackbone service",
+		zap.String("journey", "GetBackbone"),
+		zap.String("routingType", routingType),
+		zap.String("algorithm", algorithm),
+		zap.Strings("terminals", terminals),
+	)
+
+	snapshot, metric, err := pathTopologySnapshot(rs.environment, routingType)
+	if err != nil {
+		return entities.Backbone{}, err
+	}
+
+	backbone, err := computeBackbone(snapshot.Graph(), metric, algorithm, terminals)
+	backbone.Version = snapshot.Version
+
+	return backbone, err
+}
+
+func (rs topologyService) GetMaxFlow(ctx context.Context, sources, sinks []string) (entities.MaxFlow, error) {
+	logger.Info("Init GetMaxFlow service",
+		zap.String("journey", "GetMaxFlow"),
+		zap.Strings("sources", sources),
+		zap.Strings("sinks", sinks),
+	)
+
+	metric, exists := metrics.Get(capacityTopology)
+	if !exists {
+		return entities.MaxFlow{}, fmt.Errorf("unknown routing type: %s", capacityTopology)
+	}
+
+	snapshot, err := topologySnapshot(rs.environment, capacityTopology)
+	if err != nil {
+		return entities.MaxFlow{}, err
+	}
+
+	graph := snapshot.Graph()
+
+	flow, err := graph.MaxFlowMulti(sources, sinks)
+	if err != nil {
+		return entities.MaxFlow{}, err
+	}
+
+	maxFlow := entities.MaxFlow{
+		Sources: sources,
+		Sinks:   sinks,
+		Value:   metric.PathValue(flow.Value),
+		Flows:   make([]entities.LinkFlow, 0, len(flow.Flows)),
+		MinCut:  make([]entities.LinkFlow, 0, len(flow.MinCut)),
+		Version: snapshot.Version,
+	}
+
+	for arc, sent := range flow.Flows {
+		maxFlow.Flows = append(maxFlow.Flows, toLinkFlow(graph, metric, arc, sent))
+	}
+
+	slices.SortFunc(maxFlow.Flows, func(a, b entities.LinkFlow) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.Target, b.Target))
+	})
+
+	for _, arc := range flow.MinCut {
+		maxFlow.MinCut = append(maxFlow.MinCut, toLinkFlow(graph, metric, arc, flow.Flows[arc]))
+	}
+
+	return maxFlow, nil
+}
+
+// toLinkFlow converts the flow sent over arc and its capacity back to the unit
+// of the capacity metric
+func toLinkFlow(graph dijkstra.MappedGraph[string], metric entities.Metric, arc dijkstra.Arc[string], sent uint64) entities.LinkFlow {
+	capacity, _ := graph.GetArc(arc.Src, arc.Dest)
+
+	return entities.LinkFlow{
+		Route:    entities.Route{Source: arc.Src, Target: arc.Dest},
+		Flow:     metric.PathValue(sent),
+		Capacity: metric.PathValue(capacity),
+	}
+}
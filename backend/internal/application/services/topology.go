@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+	"github.com/luuisavelino/network-interface/internal/domain/entities/dijkstra"
+)
+
+// capacityTopology is the shared graph of the capacity metric, the flows are
+// computed on it. Every shared graph is named after its metric.
+const capacityTopology = "capacity"
+
+// ErrBottleneckMetric is returned when the links of a bottleneck metric are
+// asked to be summed along paths
+var ErrBottleneckMetric = errors.New("bottleneck metrics can not be summed along paths")
+
+// topologySnapshot returns the latest version of the shared graph of the
+// routing type
+func topologySnapshot(environment *entities.Environment, name string) (dijkstra.Snapshot[string], error) {
+	if _, exists := metrics.Get(name); !exists {
+		return dijkstra.Snapshot[string]{}, fmt.Errorf("unknown routing type: %s", name)
+	}
+
+	return environment.GetTopology(name).Snapshot(), nil
+}
+
+// pathTopologySnapshot returns the latest version of the shared graph of the
+// routing type and its metric, for the queries that sum the arcs of paths
+func pathTopologySnapshot(environment *entities.Environment, name string) (dijkstra.Snapshot[string], entities.Metric, error) {
+	metric, exists := metrics.Get(name)
+	if !exists {
+		return dijkstra.Snapshot[string]{}, entities.Metric{}, fmt.Errorf("unknown routing type: %s", name)
+	}
+	if metric.Combine == entities.CombineBottleneck {
+		return dijkstra.Snapshot[string]{}, entities.Metric{}, fmt.Errorf("%w: %s", ErrBottleneckMetric, name)
+	}
+
+	return environment.GetTopology(name).Snapshot(), metric, nil
+}
+
+// syncTopology replaces the links of device in every shared graph with its
+// live links, publishing a new version of each graph
+func syncTopology(environment *entities.Environment, device *entities.Device) {
+	for _, metric := range metrics.List() {
+		syncLinks(environment, metric, device, func(deviceConn string) (float64, error) {
+			return metric.Weight(environment, device, deviceConn)
+		})
+	}
+}
+
+// syncLinks replaces the arcs leaving device in the shared graph of metric,
+// weighted by linkValue. Links to devices out of the chart are left out.
+// Nothing changes once device was removed from the environment, the check is
+// made inside the update so it is ordered with the one of removeFromTopology.
+func syncLinks(environment *entities.Environment, metric entities.Metric, device *entities.Device, linkValue func(deviceConn string) (float64, error)) {
+	label := device.GetDeviceLabel()
+
+	links := make(map[string]uint64)
+	for deviceConn := range device.GetDevicesWithConn() {
+		if environment.GetDeviceInChart(deviceConn) == nil {
+			continue
+		}
+
+		weight, err := linkValue(deviceConn)
+		if err != nil {
+			continue
+		}
+
+		links[deviceConn] = metric.ArcWeight(weight)
+	}
+
+	environment.GetTopology(metric.Name).Update(func(graph *dijkstra.MappedGraph[string]) error {
+		if environment.GetDeviceByLabel(label) == nil {
+			return fmt.Errorf("device not found: %s", label)
+		}
+		addTopologyVertex(graph, label)
+
+		arcs, err := graph.GetVertexArcs(label)
+		if err != nil {
+			return err
+		}
+
+		for deviceConn := range arcs {
+			graph.RemoveArc(label, deviceConn)
+		}
+
+		for _, deviceConn := range slices.Sorted(maps.Keys(links)) {
+			if environment.GetDeviceByLabel(deviceConn) == nil {
+				continue
+			}
+			addTopologyVertex(graph, deviceConn)
+			graph.AddArc(label, deviceConn, links[deviceConn])
+		}
+
+		return nil
+	})
+}
+
+// syncMovedDevice syncs the links of device and of the devices connected to
+// it, the links that reach a device change when it moves
+func syncMovedDevice(environment *entities.Environment, device *entities.Device) {
+	syncTopology(environment, device)
+
+	for deviceConn := range device.GetDevicesWithConn() {
+		if neighbour := environment.GetDeviceByLabel(deviceConn); neighbour != nil {
+			syncTopology(environment, neighbour)
+		}
+	}
+}
+
+// addTopologyVertex adds label to the graph unless it is already there
+func addTopologyVertex(graph *dijkstra.MappedGraph[string], label string) {
+	if _, err := graph.GetVertexArcs(label); err != nil {
+		graph.AddEmptyVertex(label)
+	}
+}
+
+// removeFromTopology removes the device and its links from every shared graph
+func removeFromTopology(environment *entities.Environment, label string) {
+	for _, name := range metrics.Names() {
+		environment.GetTopology(name).Update(func(graph *dijkstra.MappedGraph[string]) error {
+			return graph.RemoveVertexAndArcs(label)
+		})
+	}
+}
+
+// computeBackbone returns the spanning tree of the graph built with algorithm,
+// or an approximate Steiner tree when terminals are given
+func computeBackbone(graph dijkstra.MappedGraph[string], metric entities.Metric, algorithm string, terminals []string) (entities.Backbone, error) {
+	var tree dijkstra.Tree[string]
+	var err error
+
+	switch {
+	case len(terminals) > 0:
+		algorithm = "steiner"
+		tree, err = graph.SteinerTree(terminals)
+	case algorithm == "prim":
+		tree = graph.PrimMST()
+	case algorithm == "kruskal":
+		tree = graph.KruskalMST()
+	case algorithm == "steiner":
+		err = fmt.Errorf("steiner backbone needs terminals")
+	default:
+		err = fmt.Errorf("unknown backbone algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return entities.Backbone{}, err
+	}
+
+	backbone := entities.Backbone{
+		Algorithm: algorithm,
+		Terminals: terminals,
+		Links:     make([]entities.Route, 0, len(tree.Edges)),
+		Weight:    metric.PathValue(tree.Weight),
+	}
+
+	for _, edge := range tree.Edges {
+		backbone.Links = append(backbone.Links, entities.Route{Source: edge.Src, Target: edge.Dest})
+	}
+
+	return backbone, nil
+}
@@ -1,8 +1,29 @@
 package entities
 
+// LinkQuality is how well a receiver gets the signal of a sender
+type LinkQuality struct {
+	RSSI      float64
+	ErrorRate float64
+}
+
+func NewLinkQuality(link RadioLink) LinkQuality {
+	return LinkQuality{
+		RSSI:      link.RSSI,
+		ErrorRate: link.ErrorRate,
+	}
+}
+
+// Connection is a link both devices confirmed they can use. ErrorRate is the
+// one of the forward direction, the one the messages take.
 type Connection struct {
 	ErrorRate float64
 	Latency   float64
+	// Capacity is the traffic the link can carry, in Mbps
+	Capacity float64
+	// Forward is how the neighbour gets this device, Reverse how this device
+	// gets the neighbour
+	Forward LinkQuality
+	Reverse LinkQuality
 }
 
 func (dw Connection) GetErrorRate() float64 {
@@ -12,3 +33,7 @@ func (dw Connection) GetErrorRate() float64 {
 func (dw Connection) GetLatency() float64 {
 	return dw.Latency
 }
+
+func (dw Connection) GetCapacity() float64 {
+	return dw.Capacity
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxConvergenceEvents is the amount of converged events kept
+const maxConvergenceEvents = 50
+
+// DeviceStaleness compares the routing table of a device with the ground truth
+// links, counted over every routing type
+type DeviceStaleness struct {
+	Label string
+	// Missing are the ground truth links the table does not have
+	Missing int
+	// Stale are the links of the table that are not in the ground truth
+	Stale int
+	// Score goes from 0, the table matches the ground truth, to 1, the table
+	// has nothing right
+	Score float64
+}
+
+// ConvergenceEvent is emitted when every routing table matches the ground
+// truth again after a change
+type ConvergenceEvent struct {
+	ChangedAt   time.Time
+	ConvergedAt time.Time
+	Elapsed     time.Duration
+}
+
+// ConvergenceStatus tells if the routing tables match the ground truth links
+type ConvergenceStatus struct {
+	Converged bool
+	// ChangedAt is when the ground truth or the tables last stopped matching
+	ChangedAt time.Time
+	// Elapsed is the time the last convergence took, or the time since the
+	// change while not converged
+	Elapsed time.Duration
+	Devices []DeviceStaleness
+	Events  []ConvergenceEvent
+}
+
+// Convergence follows the ground truth links between devices and detects
+// when the routing tables converge on them
+type Convergence struct {
+	mu        sync.Mutex
+	links     map[Route]bool
+	changedAt time.Time
+	converged bool
+	events    []ConvergenceEvent
+}
+
+func NewConvergence() *Convergence {
+	return &Convergence{
+		changedAt: time.Now(),
+		converged: true,
+	}
+}
+
+// Observe records the ground truth links and if every routing table matches
+// them. It returns the converged event when the tables just converged.
+func (c *Convergence) Observe(links map[Route]bool, consistent bool, now time.Time) (ConvergenceEvent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !maps.Equal(c.links, links) {
+		c.links = links
+		c.changedAt = now
+		c.converged = false
+	}
+
+	if !consistent {
+		if c.converged {
+			c.changedAt = now
+			c.converged = false
+		}
+		return ConvergenceEvent{}, false
+	}
+
+	if c.converged {
+		return ConvergenceEvent{}, false
+	}
+
+	c.converged = true
+	event := ConvergenceEvent{
+		ChangedAt:   c.changedAt,
+		ConvergedAt: now,
+		Elapsed:     now.Sub(c.changedAt),
+	}
+
+	c.events = append(c.events, event)
+	if len(c.events) > maxConvergenceEvents {
+		c.events = c.events[len(c.events)-maxConvergenceEvents:]
+	}
+
+	return event, true
+}
+
+// Status returns the convergence state at now, without the device staleness
+func (c *Convergence) Status(now time.Time) ConvergenceStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := ConvergenceStatus{
+		Converged: c.converged,
+		ChangedAt: c.changedAt,
+		Elapsed:   now.Sub(c.changedAt),
+		Events:    slices.Clone(c.events),
+	}
+
+	if c.converged && len(c.events) > 0 {
+		status.Elapsed = c.events[len(c.events)-1].Elapsed
+	}
+
+	return status
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/luuisavelino/network-interface/internal/domain/entities/dijkstra"
 )
 
 type Devices map[string]*Device
@@ -13,13 +15,35 @@ type Devices map[string]*Device
 type Device struct {
 	Label           string
 	Power           int
+	TxPower         float64
+	Sensitivity     float64
+	Group           string
 	Status          bool
 	Requests        Requests
 	DevicesWithConn map[string]Connection
 	ScanningDevices bool
 	RoutingTable    Routing
 
-	mu sync.Mutex
+	mu      sync.Mutex
+	history RoutingHistory
+	probes  map[string]*ProbeStats
+	routes  RouteCache
+	oneWay  map[string]OneWayLink
+
+	// tableSequence numbers the changes of the routing table
+	tableSequence uint64
+}
+
+// GetRadio returns the transmit power and the receiver sensitivity of the
+// device, in dBm
+func (d *Device) GetRadio() (txPower, sensitivity float64) {
+	d.mu.Lock()
+	txPower, sensitivity = d.TxPower, d.Sensitivity
+	d.mu.Unlock()
+	if sensitivity == 0 {
+		sensitivity = DefaultSensitivity
+	}
+	return txPower, sensitivity
 }
 
 func (d *Device) GetStatus() bool {
@@ -35,10 +59,20 @@ func (d *Device) SetStatus(status bool) {
 	d.mu.Unlock()
 }
 
+// snapshotRouting needs d.mu held. It numbers the change just made to the
+// routing table and returns a copy of it, recorded in the history once d.mu
+// is released so the diff with the latest version does not block the device.
+func (d *Device) snapshotRouting() (uint64, Routing) {
+	d.tableSequence++
+	return d.tableSequence, d.RoutingTable.Clone()
+}
+
 func (d *Device) ResetRoutingTable() {
 	d.mu.Lock()
 	d.RoutingTable = make(Routing)
+	sequence, table := d.snapshotRouting()
 	d.mu.Unlock()
+	d.history.Record(sequence, table, time.Now())
 }
 
 // TODO: use this
@@ -61,21 +95,58 @@ func (d *Device) GetDevicesWithConn() map[string]Connection {
 	return d.DevicesWithConn
 }
 
-func (d *Device) SetDeviceWithConn(device string, errorRate, latency float64) {
+func (d *Device) SetDeviceWithConn(device string, connection Connection) {
 	d.mu.Lock()
-	d.DevicesWithConn[device] = Connection{
-		ErrorRate: errorRate,
-		Latency:   latency,
-	}
+	d.DevicesWithConn[device] = connection
+	delete(d.oneWay, device)
 	d.mu.Unlock()
 }
 
+// ResetDeviceConn forgets the neighbours, the confirmed ones and the one-way
+// ones, before a new scan
 func (d *Device) ResetDeviceConn() {
 	d.mu.Lock()
 	d.DevicesWithConn = make(map[string]Connection)
+	d.oneWay = nil
 	d.mu.Unlock()
 }
 
+// SetOneWayLink keeps a neighbour the handshake could not confirm, unless it is
+// already connected
+func (d *Device) SetOneWayLink(link OneWayLink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, connected := d.DevicesWithConn[link.Neighbour]; connected {
+		return
+	}
+	if d.oneWay == nil {
+		d.oneWay = make(map[string]OneWayLink)
+	}
+	d.oneWay[link.Neighbour] = link
+}
+
+// GetOneWayLinks returns the neighbours the handshake could not confirm,
+// sorted by label
+func (d *Device) GetOneWayLinks() []OneWayLink {
+	d.mu.Lock()
+	links := make([]OneWayLink, 0, len(d.oneWay))
+	for _, link := range d.oneWay {
+		links = append(links, link)
+	}
+	d.mu.Unlock()
+
+	sortOneWayLinks(links)
+	return links
+}
+
+func (d *Device) GetGroup() string {
+	d.mu.Lock()
+	group := d.Group
+	d.mu.Unlock()
+	return group
+}
+
 func (d *Device) GetDeviceLabel() string {
 	d.mu.Lock()
 	label := d.Label
@@ -83,28 +154,30 @@ func (d *Device) GetDeviceLabel() string {
 	return label
 }
 
+// AddRouting merges routingTable into the table of the device. An entry
+// replaces the known one when it was announced later, or with fewer hops.
 func (d *Device) AddRouting(routingTable Routing) {
 	d.mu.Lock()
-	for routeType, route := range routingTable {
-		if _, ok := d.RoutingTable[routeType]; !ok {
-			d.RoutingTable[routeType] = make(map[string]map[string]float64)
-			d.RoutingTable[routeType] = route
-			continue
-		}
+	d.addRouting(routingTable)
+	sequence, table := d.snapshotRouting()
+	d.mu.Unlock()
+	d.history.Record(sequence, table, time.Now())
+}
 
-		for sourceLabel, target := range route {
-			if _, ok := d.RoutingTable[routeType][sourceLabel]; !ok {
-				d.RoutingTable[routeType][sourceLabel] = make(map[string]float64)
-				d.RoutingTable[routeType][sourceLabel] = target
-				continue
-			}
+func (d *Device) addRouting(routingTable Routing) {
+	for routeType, sources := range routingTable {
+		for sourceLabel, targets := range sources {
+			for targetLabel, entry := range targets {
+				known, exists := d.RoutingTable[routeType][sourceLabel][targetLabel]
+				if exists && !entry.ExpiresAt.After(known.ExpiresAt) &&
+					(!entry.ExpiresAt.Equal(known.ExpiresAt) || entry.HopCount >= known.HopCount) {
+					continue
+				}
 
-			for targetLabel, weight := range target {
-				d.RoutingTable[routeType][sourceLabel][targetLabel] = weight
+				d.RoutingTable.Set(routeType, sourceLabel, targetLabel, entry)
 			}
 		}
 	}
-	d.mu.Unlock()
 }
 
 func (d *Device) RemoveRoutings(routeType, source, target string) {
@@ -113,25 +186,64 @@ func (d *Device) RemoveRoutings(routeType, source, target string) {
 	if exists {
 		delete(route, target)
 	}
+	sequence, table := d.snapshotRouting()
 	d.mu.Unlock()
+	d.history.Record(sequence, table, time.Now())
 }
 
+// RemoveFromTableRoutesWith removes the links from or to deviceLabel
 func (d *Device) RemoveFromTableRoutesWith(deviceLabel string) {
 	d.mu.Lock()
+	d.removeRoutesWith(deviceLabel)
+	sequence, table := d.snapshotRouting()
+	d.mu.Unlock()
+	d.history.Record(sequence, table, time.Now())
+}
+
+func (d *Device) removeRoutesWith(deviceLabel string) {
 	for _, routes := range d.RoutingTable {
-		for sourceLabel, _ := range routes {
-			if sourceLabel == deviceLabel {
-				delete(d.RoutingTable, sourceLabel)
-				continue
+		delete(routes, deviceLabel)
+
+		for _, targets := range routes {
+			delete(targets, deviceLabel)
+		}
+	}
+}
+
+// ReplaceRoutesWith replaces the links from or to deviceLabel with the ones
+// merged from routingTable, as a single change of the table
+func (d *Device) ReplaceRoutesWith(deviceLabel string, routingTable Routing) {
+	d.mu.Lock()
+	d.removeRoutesWith(deviceLabel)
+	d.addRouting(routingTable)
+	sequence, table := d.snapshotRouting()
+	d.mu.Unlock()
+	d.history.Record(sequence, table, time.Now())
+}
+
+// PurgeExpiredRoutes removes the entries expired at now and returns how many
+// were removed
+func (d *Device) PurgeExpiredRoutes(now time.Time) int {
+	d.mu.Lock()
+	purged := 0
+	for _, routes := range d.RoutingTable {
+		for sourceLabel, targets := range routes {
+			for targetLabel, entry := range targets {
+				if entry.Expired(now) {
+					delete(targets, targetLabel)
+					purged++
+				}
 			}
 
-			route, exists := d.RoutingTable[sourceLabel][deviceLabel]
-			if exists {
-				delete(route, deviceLabel)
+			if len(targets) == 0 {
+				delete(routes, sourceLabel)
 			}
 		}
 	}
+	sequence, table := d.snapshotRouting()
 	d.mu.Unlock()
+	d.history.Record(sequence, table, now)
+	return purged
 }
 
 func (d *Device) GetRoutingTable() Routing {
@@ -142,6 +254,158 @@ func (d *Device) GetRoutingTable() Routing {
 	return routingTable
 }
 
+// CloneRoutingTable returns a copy of the routing table that is safe to read
+// while the device keeps updating its own
+func (d *Device) CloneRoutingTable() Routing {
+	d.mu.Lock()
+	routingTable := d.RoutingTable.Clone()
+	d.mu.Unlock()
+	return routingTable
+}
+
+// GetRoutingHistory returns the versions of the routing table kept, oldest
+// first
+func (d *Device) GetRoutingHistory() []RoutingVersion {
+	return d.history.Versions()
+}
+
+// GetRoutingVersion returns a version of the routing table if it is still
+// kept
+func (d *Device) GetRoutingVersion(version uint64) (RoutingVersion, bool) {
+	return d.history.Get(version)
+}
+
+// GetRoutingAt returns the version of the routing table current at t
+func (d *Device) GetRoutingAt(t time.Time) (RoutingVersion, bool) {
+	return d.history.At(t)
+}
+
+// GetLatestRoutingVersion returns the newest version of the routing table
+func (d *Device) GetLatestRoutingVersion() (RoutingVersion, bool) {
+	return d.history.Latest()
+}
+
+// RoutingTableVersion returns the number of the current version of the
+// routing table, 0 while nothing was recorded
+func (d *Device) RoutingTableVersion() uint64 {
+	latest, _ := d.history.Latest()
+	return latest.Version
+}
+
+// GetCachedRoute returns the cached path to target for the routing type, if it
+// was found with the current routing table
+func (d *Device) GetCachedRoute(target, routingType string) ([]Route, bool) {
+	latest, _ := d.history.Latest()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.routes.Get(target, routingType, latest.Version)
+}
+
+// CacheRoute keeps the path to target for the routing type, found with the
+// routing table at tableVersion
+func (d *Device) CacheRoute(target, routingType string, tableVersion uint64, routes []Route) {
+	d.mu.Lock()
+	d.routes.Put(target, routingType, tableVersion, routes)
+	d.mu.Unlock()
+}
+
+// InvalidateCachedRoutes drops the cached paths that use a broken link and
+// returns how many were dropped
+func (d *Device) InvalidateCachedRoutes(broken func(link Route) bool) int {
+	d.mu.Lock()
+	dropped := d.routes.Invalidate(broken)
+	d.mu.Unlock()
+	return dropped
+}
+
+// BreakLinks marks the links as broken, they are left out of the routing graph
+// and of the cached paths until they come back. It returns how many cached
+// paths were dropped.
+func (d *Device) BreakLinks(links []Route) int {
+	d.mu.Lock()
+	dropped := d.routes.Break(links)
+	d.mu.Unlock()
+	return dropped
+}
+
+// RestoreLinks unmarks the links that came back
+func (d *Device) RestoreLinks(links []Route) {
+	d.mu.Lock()
+	d.routes.Restore(links)
+	d.mu.Unlock()
+}
+
+// RoutingGraph builds the graph of the links known by the routing table of the
+// device for the metric between labels, weighted so paths combine the way the
+// metric does. The links marked as broken are left out.
+func (d *Device) RoutingGraph(metric Metric, labels []string) dijkstra.MappedGraph[string] {
+	graph := dijkstra.NewMappedGraph[string]()
+	for _, label := range labels {
+		graph.AddEmptyVertex(label)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for sourceLabel, targets := range d.RoutingTable[metric.Name] {
+		for targetLabel, entry := range targets {
+			if d.routes.IsBroken(Route{Source: sourceLabel, Target: targetLabel}) {
+				continue
+			}
+			graph.AddArc(sourceLabel, targetLabel, metric.ArcWeight(entry.Weight))
+		}
+	}
+
+	return graph
+}
+
+func (d *Device) GetRouteCacheStats() RouteCacheStats {
+	d.mu.Lock()
+	stats := d.routes.Stats()
+	d.mu.Unlock()
+	return stats
+}
+
+// ReceiveProbe records a probe from sender, reported is the ratio of the probes
+// of the device sender received
+func (d *Device) ReceiveProbe(sender string, reported float64, now time.Time) {
+	d.mu.Lock()
+	if d.probes == nil {
+		d.probes = make(map[string]*ProbeStats)
+	}
+	if d.probes[sender] == nil {
+		d.probes[sender] = &ProbeStats{}
+	}
+	d.probes[sender].Receive(reported, now)
+	d.mu.Unlock()
+}
+
+// GetProbeRatios returns the ratio of the probes received from every
+// neighbour heard in the window
+func (d *Device) GetProbeRatios(now time.Time) map[string]float64 {
+	d.mu.Lock()
+	ratios := make(map[string]float64)
+	for neighbour, stats := range d.probes {
+		if ratio := stats.Ratio(now); ratio > 0 {
+			ratios[neighbour] = ratio
+		}
+	}
+	d.mu.Unlock()
+	return ratios
+}
+
+// GetETX returns the ETX of the link to neighbour, false while there are not
+// enough probes to estimate it
+func (d *Device) GetETX(neighbour string, now time.Time) (float64, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats, exists := d.probes[neighbour]
+	if !exists {
+		return 0, false
+	}
+	return stats.ETX(now)
+}
+
 func (d *Device) GetUnreadRequests() map[uuid.UUID]*Request {
 	d.mu.Lock()
 	unreadRequests := make(map[uuid.UUID]*Request)
@@ -192,19 +456,19 @@ func (d *Device) PrintPrettyTable() {
 		return
 	}
 
-	fmt.Printf("%s\n", strings.Repeat("-", 40))
-	fmt.Printf("| %-10s | %-24s | \n", "Device", d.GetDeviceLabel())
-	fmt.Printf("%s\n", strings.Repeat("-", 40))
-	fmt.Printf("| %-10s | %-6s | %-6s | %-6s | \n", "Type", "Source", "Target", "Weight")
-	fmt.Printf("%s\n", strings.Repeat("-", 40))
+	fmt.Printf("%s\n", strings.Repeat("-", 58))
+	fmt.Printf("| %-10s | %-42s | \n", "Device", d.GetDeviceLabel())
+	fmt.Printf("%s\n", strings.Repeat("-", 58))
+	fmt.Printf("| %-10s | %-6s | %-6s | %-6s | %-4s | %-8s | \n", "Type", "Source", "Target", "Weight", "Hops", "Next hop")
+	fmt.Printf("%s\n", strings.Repeat("-", 58))
 	for routingType, routes := range table {
 		for sourceLabel, target := range routes {
-			for targetLabel, weight := range target  {
-				fmt.Printf("| %-10s | %-6s | %-6s | %-6.2f |\n", routingType, sourceLabel, targetLabel, weight)
+			for targetLabel, entry := range target  {
+				fmt.Printf("| %-10s | %-6s | %-6s | %-6.2f | %-4d | %-8s |\n", routingType, sourceLabel, targetLabel, entry.Weight, entry.HopCount, entry.NextHop)
 			}
 		}
 	}
-	fmt.Printf("%s\n", strings.Repeat("-", 40))
+	fmt.Printf("%s\n", strings.Repeat("-", 58))
 }
 
 func (d *Device) DeleteRequest(RequestId uuid.UUID) {
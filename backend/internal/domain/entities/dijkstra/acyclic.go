@@ -0,0 +1,242 @@
+package dijkstra
+
+import (
+	"errors"
+	"slices"
+)
+
+// FindCycle returns the vertices of a cycle of the graph in order, the last
+// vertex has an arc to the first one. It returns nil if the graph is acyclic.
+// Loops on a single vertex count as cycles.
+func (g Graph) FindCycle() []int {
+	return g.findCycle(-1)
+}
+
+// FindCycleFrom returns a cycle reachable from src like FindCycle, cycles that
+// src can not reach are ignored
+func (g Graph) FindCycleFrom(src int) ([]int, error) {
+	if err := g.vertexValid(src); err != nil {
+		return nil, err
+	}
+	return g.findCycle(src), nil
+}
+
+// findCycle runs an iterative depth first search from src, or from every
+// vertex if src is -1, and returns the first back arc found as a cycle
+func (g Graph) findCycle(src int) []int {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	adjacency := g.sortedAdjacency()
+	state := make([]int, len(g.vertexArcs))
+	type frame struct {
+		vertex int
+		arc    int
+	}
+	roots := []int{src}
+	if src == -1 {
+		roots = roots[:0]
+		for v, arcs := range g.vertexArcs {
+			if arcs != nil {
+				roots = append(roots, v)
+			}
+		}
+	}
+	for _, root := range roots {
+		if state[root] != unvisited {
+			continue
+		}
+		state[root] = onPath
+		path := []frame{{root, 0}}
+		for len(path) > 0 {
+			top := &path[len(path)-1]
+			if top.arc == len(adjacency[top.vertex]) {
+				state[top.vertex] = done
+				path = path[:len(path)-1]
+				continue
+			}
+			to := adjacency[top.vertex][top.arc]
+			top.arc++
+			switch state[to] {
+			case unvisited:
+				state[to] = onPath
+				path = append(path, frame{to, 0})
+			case onPath:
+				var cycle []int
+				for i := len(path) - 1; i >= 0; i-- {
+					cycle = append(cycle, path[i].vertex)
+					if path[i].vertex == to {
+						break
+					}
+				}
+				slices.Reverse(cycle)
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// TopologicalSort returns the vertices ordered so that every arc goes from an
+// earlier vertex to a later one, ties are broken by index. It fails with a
+// CycleError if the graph has a cycle.
+func (g Graph) TopologicalSort() ([]int, error) {
+	inDegree := make([]int, len(g.vertexArcs))
+	for _, arcs := range g.vertexArcs {
+		for to := range arcs {
+			inDegree[to]++
+		}
+	}
+	adjacency := g.sortedAdjacency()
+	visiting := g.getList(listShortPQ)
+	for v, arcs := range g.vertexArcs {
+		if arcs != nil && inDegree[v] == 0 {
+			visiting.PushOrdered(currentDistance{v, uint64(v)})
+		}
+	}
+	var order []int
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		order = append(order, current.id)
+		for _, to := range adjacency[current.id] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				visiting.PushOrdered(currentDistance{to, uint64(to)})
+			}
+		}
+	}
+	if len(order) != g.vertexCount() {
+		return nil, newErrCycle(g.FindCycle())
+	}
+	return order, nil
+}
+
+// LongestDAG calculates the longest path from src to dest in linear time, by
+// relaxing the vertices reachable from src in topological order. Unlike
+// Longest it only fails on a cycle reachable from src, with a CycleError.
+func (g Graph) LongestDAG(src, dest int) (BestPath[int], error) {
+	if err := g.searchValid(src, dest); err != nil {
+		return BestPath[int]{}, err
+	}
+	if cycle := g.findCycle(src); cycle != nil {
+		return BestPath[int]{}, newErrCycle(cycle)
+	}
+	// depth first post order from src, reversed, is a topological order of
+	// the vertices src reaches
+	adjacency := g.sortedAdjacency()
+	visited := make([]bool, len(g.vertexArcs))
+	var postOrder []int
+	type frame struct {
+		vertex int
+		arc    int
+	}
+	visited[src] = true
+	path := []frame{{src, 0}}
+	for len(path) > 0 {
+		top := &path[len(path)-1]
+		if top.arc == len(adjacency[top.vertex]) {
+			postOrder = append(postOrder, top.vertex)
+			path = path[:len(path)-1]
+			continue
+		}
+		to := adjacency[top.vertex][top.arc]
+		top.arc++
+		if !visited[to] {
+			visited[to] = true
+			path = append(path, frame{to, 0})
+		}
+	}
+	if !visited[dest] {
+		return BestPath[int]{}, newErrNoPath(src, dest)
+	}
+
+	distances := make([]uint64, len(g.vertexArcs))
+	previous := make([]int, len(g.vertexArcs))
+	for i := range previous {
+		previous[i] = -1
+	}
+	for i := len(postOrder) - 1; i >= 0; i-- {
+		v := postOrder[i]
+		for _, to := range adjacency[v] {
+			distance := distances[v] + g.vertexArcs[v][to]
+			if previous[to] == -1 || distance > distances[to] {
+				distances[to] = distance
+				previous[to] = v
+			}
+		}
+	}
+	return BestPath[int]{distances[dest], buildPath(previous, src, dest)}, nil
+}
+
+func (g Graph) vertexCount() int {
+	count := 0
+	for _, arcs := range g.vertexArcs {
+		if arcs != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// FindCycle returns the vertices of a cycle of the graph in order, or nil if
+// the graph is acyclic
+func (mg MappedGraph[T]) FindCycle() []T {
+	return mg.toMappedVertices(mg.graph.FindCycle())
+}
+
+// FindCycleFrom returns a cycle reachable from src, or nil if there is none
+func (mg MappedGraph[T]) FindCycleFrom(src T) ([]T, error) {
+	id, err := mg.getMap(src)
+	if err != nil {
+		return nil, err
+	}
+	cycle, err := mg.graph.FindCycleFrom(id)
+	return mg.toMappedVertices(cycle), err
+}
+
+// TopologicalSort returns the vertices ordered so that every arc goes from an
+// earlier vertex to a later one
+func (mg MappedGraph[T]) TopologicalSort() ([]T, error) {
+	order, err := mg.graph.TopologicalSort()
+	if err != nil {
+		return nil, mg.toMappedErr(err)
+	}
+	return mg.toMappedVertices(order), nil
+}
+
+// LongestDAG calculates the longest path from src to dest, it fails if a cycle
+// is reachable from src
+func (mg MappedGraph[T]) LongestDAG(src, dest T) (BestPath[T], error) {
+	srcID, destID, err := mg.getMap2(src, dest)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	best, err := mg.graph.LongestDAG(srcID, destID)
+	if err != nil {
+		return BestPath[T]{}, mg.toMappedErr(err)
+	}
+	return mg.toMappedBestPath(best)
+}
+
+func (mg MappedGraph[T]) toMappedVertices(cycle []int) []T {
+	if cycle == nil {
+		return nil
+	}
+	vertices := mg.indexedVertices()
+	mapped := make([]T, len(cycle))
+	for i, v := range cycle {
+		mapped[i] = vertices[v]
+	}
+	return mapped
+}
+
+// toMappedErr maps the vertices of a CycleError
+func (mg MappedGraph[T]) toMappedErr(err error) error {
+	var cycleErr CycleError[int]
+	if errors.As(err, &cycleErr) {
+		return CycleError[T]{mg.toMappedVertices(cycleErr.Cycle)}
+	}
+	return err
+}
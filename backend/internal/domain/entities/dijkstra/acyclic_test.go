@@ -0,0 +1,153 @@
+package dijkstra
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// dagTestGraph is acyclic, the longest path from 0 to 5 goes through every
+// vertex and is 17 long
+func dagTestGraph() Graph {
+	return Graph{
+		[]map[int]uint64{
+			{1: 5, 2: 3},
+			{3: 6, 2: 2},
+			{4: 4, 5: 2, 3: 7},
+			{5: 1, 4: 1},
+			{5: 2},
+			{},
+		},
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	if cycle := dagTestGraph().FindCycle(); cycle != nil {
+		t.Error("dag has no cycle", cycle)
+	}
+	g := Graph{
+		[]map[int]uint64{
+			{1: 1},
+			{2: 1},
+			{3: 1},
+			{1: 1},
+			{0: 1},
+		},
+	}
+	if cycle := g.FindCycle(); !reflect.DeepEqual(cycle, []int{1, 2, 3}) {
+		t.Error("wrong cycle", cycle)
+	}
+	cycle, err := g.FindCycleFrom(2)
+	testErrors(t, nil, err, 0)
+	if !reflect.DeepEqual(cycle, []int{2, 3, 1}) {
+		t.Error("wrong cycle from 2", cycle)
+	}
+	g.RemoveArc(3, 1)
+	if cycle, _ := g.FindCycleFrom(0); cycle != nil {
+		t.Error("no cycle is reachable from 0", cycle)
+	}
+	g.AddArc(3, 3, 1)
+	if cycle := g.FindCycle(); !reflect.DeepEqual(cycle, []int{3}) {
+		t.Error("a loop is a cycle", cycle)
+	}
+	_, err = g.FindCycleFrom(9)
+	testErrors(t, ErrVertexNotFound, err, 1)
+}
+
+func TestTopologicalSort(t *testing.T) {
+	order, err := dagTestGraph().TopologicalSort()
+	testErrors(t, nil, err, 0)
+	if !reflect.DeepEqual(order, []int{0, 1, 2, 3, 4, 5}) {
+		t.Error("wrong order", order)
+	}
+	g := dagTestGraph()
+	g.AddArc(5, 0, 1)
+	_, err = g.TopologicalSort()
+	testErrors(t, ErrLoopDetected, err, 1)
+	var cycleErr CycleError[int]
+	if !errors.As(err, &cycleErr) || len(cycleErr.Cycle) == 0 {
+		t.Fatal("error should have the cycle", err)
+	}
+	for i, v := range cycleErr.Cycle {
+		next := cycleErr.Cycle[(i+1)%len(cycleErr.Cycle)]
+		if _, err := g.GetArc(v, next); err != nil {
+			t.Error("cycle is not made of arcs", cycleErr.Cycle)
+		}
+	}
+}
+
+func TestLongestDAG(t *testing.T) {
+	g := dagTestGraph()
+	best, err := g.LongestDAG(0, 5)
+	testErrors(t, nil, err, 0)
+	testResults(t, BestPath[int]{17, []int{0, 1, 2, 3, 4, 5}}, best, false, 0)
+
+	// a cycle src can not reach does not matter
+	g.AddEmptyVertex(6)
+	g.AddEmptyVertex(7)
+	g.AddArc(6, 7, 1)
+	g.AddArc(7, 6, 1)
+	g.AddArc(7, 0, 1)
+	best, err = g.LongestDAG(0, 5)
+	testErrors(t, nil, err, 1)
+	if best.Distance != 17 {
+		t.Error("wrong distance", best)
+	}
+	_, err = g.LongestDAG(0, 6)
+	testErrors(t, ErrNoPath, err, 2)
+	_, err = g.LongestDAG(7, 5)
+	testErrors(t, ErrLoopDetected, err, 3)
+
+	for i, test := range testGraphsCorrect {
+		if test.graph.FindCycle() != nil || test.evalErr != nil {
+			continue
+		}
+		got, err := test.graph.LongestDAG(test.from, test.to)
+		testErrors(t, nil, err, i)
+		if got.Distance != test.longestSolution.Distance {
+			t.Error("longest dag differs from longest", i, got, test.longestSolution)
+		}
+	}
+}
+
+func TestLongestReportsCycle(t *testing.T) {
+	graph := Graph{
+		[]map[int]uint64{
+			{1: 1, 2: 0},
+			{2: 5},
+			{1: 10, 3: 10},
+			{},
+		},
+	}
+	_, err := graph.Longest(0, 3)
+	var cycleErr CycleError[int]
+	if !errors.As(err, &cycleErr) || !reflect.DeepEqual(cycleErr.Cycle, []int{1, 2}) {
+		t.Error("longest should report the cycle", err)
+	}
+
+	mg := NewMappedGraph[string]()
+	for _, v := range []string{"A", "B", "C"} {
+		mg.AddEmptyVertex(v)
+	}
+	mg.AddArc("A", "B", 1)
+	mg.AddArc("B", "C", 1)
+	mg.AddArc("C", "B", 1)
+	_, err = mg.LongestDAG("A", "C")
+	var mappedErr CycleError[string]
+	if !errors.As(err, &mappedErr) || !reflect.DeepEqual(mappedErr.Cycle, []string{"B", "C"}) {
+		t.Error("mapped error should have the mapped cycle", err)
+	}
+	if cycle := mg.FindCycle(); !reflect.DeepEqual(cycle, []string{"B", "C"}) {
+		t.Error("wrong mapped cycle", cycle)
+	}
+	mg.RemoveArc("C", "B")
+	best, err := mg.LongestDAG("A", "C")
+	testErrors(t, nil, err, 0)
+	if !reflect.DeepEqual(best.Path, []string{"A", "B", "C"}) {
+		t.Error("wrong mapped path", best)
+	}
+	order, _ := mg.TopologicalSort()
+	if !reflect.DeepEqual(order, []string{"A", "B", "C"}) {
+		t.Error("wrong mapped order", order)
+	}
+}
@@ -0,0 +1,271 @@
+package dijkstra
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Infinity is the distance between two vertices that have no path between them
+const Infinity = uint64(math.MaxUint64)
+
+// AllPairs contains the shortest distance and the next hop between every pair
+// of vertices, indexed [src][dest]
+type AllPairs struct {
+	// Distances is Infinity if dest can not be reached from src
+	Distances [][]uint64
+	// NextHops is the first vertex after src on the way to dest, -1 if dest
+	// can not be reached or src == dest
+	NextHops [][]int
+}
+
+// Path rebuilds the shortest path from src to dest following the next hops
+func (ap AllPairs) Path(src, dest int) (BestPath[int], error) {
+	if src < 0 || src >= len(ap.Distances) {
+		return BestPath[int]{}, newErrVertexNotFound(src)
+	}
+	if dest < 0 || dest >= len(ap.Distances) {
+		return BestPath[int]{}, newErrVertexNotFound(dest)
+	}
+	if ap.Distances[src][dest] == Infinity {
+		return BestPath[int]{}, newErrNoPath(src, dest)
+	}
+	path := []int{src}
+	for current := src; current != dest; {
+		current = ap.NextHops[current][dest]
+		if current == -1 || len(path) > len(ap.Distances) {
+			return BestPath[int]{}, newErrNoPath(src, dest)
+		}
+		path = append(path, current)
+	}
+	return BestPath[int]{ap.Distances[src][dest], path}, nil
+}
+
+func newAllPairs(vertices int) AllPairs {
+	ap := AllPairs{
+		Distances: make([][]uint64, vertices),
+		NextHops:  make([][]int, vertices),
+	}
+	for i := range vertices {
+		ap.Distances[i] = make([]uint64, vertices)
+		ap.NextHops[i] = make([]int, vertices)
+		for j := range vertices {
+			ap.Distances[i][j] = Infinity
+			ap.NextHops[i][j] = -1
+		}
+	}
+	return ap
+}
+
+// AllPairsShortest calculates the shortest path between every pair of
+// vertices, using Floyd-Warshall for dense graphs and parallel Dijkstra runs
+// otherwise
+func (g Graph) AllPairsShortest() AllPairs {
+	vertices, arcs := 0, 0
+	for _, v := range g.vertexArcs {
+		if v != nil {
+			vertices++
+			arcs += len(v)
+		}
+	}
+	if 2*arcs >= vertices*(vertices-1) {
+		return g.FloydWarshall()
+	}
+	return g.AllPairsDijkstra(runtime.GOMAXPROCS(0))
+}
+
+// AllPairsDijkstra calculates the shortest path between every pair of vertices
+// running one Dijkstra per source, spread across workers goroutines
+func (g Graph) AllPairsDijkstra(workers int) AllPairs {
+	if workers < 1 {
+		workers = 1
+	}
+	ap := newAllPairs(len(g.vertexArcs))
+	sources := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for src := range sources {
+				tree := g.shortestFrom(src)
+				ap.Distances[src] = tree.distances
+				ap.NextHops[src] = tree.firstHops
+			}
+		}()
+	}
+	for src, v := range g.vertexArcs {
+		if v != nil {
+			sources <- src
+		}
+	}
+	close(sources)
+	wg.Wait()
+	return ap
+}
+
+// FloydWarshall calculates the shortest path between every pair of vertices
+// with the Floyd-Warshall algorithm, which suits dense graphs
+func (g Graph) FloydWarshall() AllPairs {
+	ap := newAllPairs(len(g.vertexArcs))
+	for src, v := range g.vertexArcs {
+		if v == nil {
+			continue
+		}
+		ap.Distances[src][src] = 0
+		for dest, dist := range v {
+			if dest != src && dist < ap.Distances[src][dest] {
+				ap.Distances[src][dest] = dist
+				ap.NextHops[src][dest] = dest
+			}
+		}
+	}
+	for k := range ap.Distances {
+		for i := range ap.Distances {
+			ik := ap.Distances[i][k]
+			if ik == Infinity {
+				continue
+			}
+			for j := range ap.Distances {
+				kj := ap.Distances[k][j]
+				if kj == Infinity || ik+kj < ik {
+					continue
+				}
+				if ik+kj < ap.Distances[i][j] {
+					ap.Distances[i][j] = ik + kj
+					ap.NextHops[i][j] = ap.NextHops[i][k]
+				}
+			}
+		}
+	}
+	return ap
+}
+
+// shortestTree is the result of a single source Dijkstra run to every vertex
+type shortestTree struct {
+	distances []uint64
+	previous  []int
+	firstHops []int
+	// expanded is the amount of vertices taken out of the list
+	expanded int
+}
+
+// shortestFrom runs Dijkstra from src to every vertex of the graph
+func (g Graph) shortestFrom(src int) shortestTree {
+	tree := shortestTree{
+		distances: make([]uint64, len(g.vertexArcs)),
+		previous:  make([]int, len(g.vertexArcs)),
+		firstHops: make([]int, len(g.vertexArcs)),
+	}
+	for i := range tree.distances {
+		tree.distances[i] = Infinity
+		tree.previous[i] = -1
+		tree.firstHops[i] = -1
+	}
+	if g.vertexValid(src) != nil {
+		return tree
+	}
+	tree.distances[src] = 0
+	visiting := g.getList(listShortPQ)
+	visiting.PushOrdered(currentDistance{src, 0})
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		if current.distance > tree.distances[current.id] {
+			continue
+		}
+		tree.expanded++
+		for to, dist := range g.vertexArcs[current.id] {
+			distance := current.distance + dist
+			if distance < current.distance {
+				// overflow
+				continue
+			}
+			if distance < tree.distances[to] {
+				tree.distances[to] = distance
+				tree.previous[to] = current.id
+				if current.id == src {
+					tree.firstHops[to] = to
+				} else {
+					tree.firstHops[to] = tree.firstHops[current.id]
+				}
+				visiting.PushOrdered(currentDistance{to, distance})
+			}
+		}
+	}
+	return tree
+}
+
+// MappedAllPairs contains the shortest distance and the next hop between every
+// pair of mapped vertices, indexed by the position of the vertex in Vertices
+type MappedAllPairs[T comparable] struct {
+	Vertices []T
+	// Distances is Infinity if dest can not be reached from src
+	Distances [][]uint64
+	// NextHops is the position in Vertices of the first vertex after src on
+	// the way to dest, -1 if dest can not be reached or src == dest
+	NextHops [][]int
+}
+
+// AllPairsShortest calculates the shortest path between every pair of
+// vertices, Vertices are ordered by the order they were added to the graph
+func (mg MappedGraph[T]) AllPairsShortest() MappedAllPairs[T] {
+	return mg.toMappedAllPairs(mg.graph.AllPairsShortest())
+}
+
+func (mg MappedGraph[T]) toMappedAllPairs(ap AllPairs) MappedAllPairs[T] {
+	vertices := mg.orderedVertices()
+	position := make([]int, len(mg.graph.vertexArcs))
+	for i := range position {
+		position[i] = -1
+	}
+	for i, v := range vertices {
+		position[mg.mapping[v]] = i
+	}
+	result := MappedAllPairs[T]{
+		Vertices:  vertices,
+		Distances: make([][]uint64, len(vertices)),
+		NextHops:  make([][]int, len(vertices)),
+	}
+	for i, src := range vertices {
+		srcId := mg.mapping[src]
+		result.Distances[i] = make([]uint64, len(vertices))
+		result.NextHops[i] = make([]int, len(vertices))
+		for j, dest := range vertices {
+			destId := mg.mapping[dest]
+			result.Distances[i][j] = ap.Distances[srcId][destId]
+			result.NextHops[i][j] = -1
+			if hop := ap.NextHops[srcId][destId]; hop != -1 {
+				result.NextHops[i][j] = position[hop]
+			}
+		}
+	}
+	return result
+}
+
+// Path rebuilds the shortest path from src to dest following the next hops
+func (ap MappedAllPairs[T]) Path(src, dest T) (BestPath[T], error) {
+	from, to := -1, -1
+	for i, v := range ap.Vertices {
+		if v == src {
+			from = i
+		}
+		if v == dest {
+			to = i
+		}
+	}
+	if from == -1 {
+		return BestPath[T]{}, newErrMappedVertexNotFound(src)
+	}
+	if to == -1 {
+		return BestPath[T]{}, newErrMappedVertexNotFound(dest)
+	}
+	bp, err := AllPairs{ap.Distances, ap.NextHops}.Path(from, to)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	result := BestPath[T]{Distance: bp.Distance, Path: make([]T, len(bp.Path))}
+	for i, v := range bp.Path {
+		result.Path[i] = ap.Vertices[v]
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package dijkstra
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestAllPairs(t *testing.T) {
+	graphs := []Graph{Generate(40), constrainedTestGraph()}
+	for _, test := range testGraphsCorrect {
+		graphs = append(graphs, test.graph)
+	}
+	solvers := []struct {
+		name  string
+		solve func(Graph) AllPairs
+	}{
+		{"Auto", Graph.AllPairsShortest},
+		{"Dijkstra", func(g Graph) AllPairs { return g.AllPairsDijkstra(4) }},
+		{"FloydWarshall", Graph.FloydWarshall},
+	}
+	for _, solver := range solvers {
+		t.Run(solver.name, func(t *testing.T) {
+			for gi, g := range graphs {
+				ap := solver.solve(g)
+				for src := range g.vertexArcs {
+					for dest := range g.vertexArcs {
+						want, err := g.Shortest(src, dest)
+						if src == dest {
+							if ap.Distances[src][dest] != 0 || ap.NextHops[src][dest] != -1 {
+								t.Errorf("graph %d: %d->%d should be 0 with no next hop", gi, src, dest)
+							}
+							continue
+						}
+						got, gotErr := ap.Path(src, dest)
+						if errors.Is(err, ErrNoPath) {
+							testErrors(t, ErrNoPath, gotErr, gi)
+							if ap.Distances[src][dest] != Infinity {
+								t.Errorf("graph %d: %d->%d should be unreachable", gi, src, dest)
+							}
+							continue
+						}
+						testErrors(t, nil, gotErr, gi)
+						if got.Distance != want.Distance || ap.Distances[src][dest] != want.Distance {
+							t.Errorf("graph %d: %d->%d distance %d, want %d", gi, src, dest, got.Distance, want.Distance)
+						}
+						if got.Path[0] != src || got.Path[len(got.Path)-1] != dest {
+							t.Errorf("graph %d: %d->%d wrong path %v", gi, src, dest, got.Path)
+						}
+						var distance uint64
+						for i := 0; i < len(got.Path)-1; i++ {
+							arc, err := g.GetArc(got.Path[i], got.Path[i+1])
+							if err != nil {
+								t.Fatalf("graph %d: path %v uses missing arc", gi, got.Path)
+							}
+							distance += arc
+						}
+						if distance != got.Distance {
+							t.Errorf("graph %d: path %v adds up to %d, want %d", gi, got.Path, distance, got.Distance)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAllPairsRemovedVertex(t *testing.T) {
+	g := NewGraph()
+	for range 3 {
+		g.AddNewEmptyVertex()
+	}
+	g.AddArc(0, 1, 1)
+	g.AddArc(1, 2, 1)
+	g.RemoveVertexAndArcs(1)
+	for _, ap := range []AllPairs{g.AllPairsDijkstra(2), g.FloydWarshall()} {
+		if ap.Distances[0][2] != Infinity || ap.Distances[1][1] != Infinity {
+			t.Error("removed vertex should not be reachable", ap.Distances)
+		}
+	}
+}
+
+func TestAllPairsMapped(t *testing.T) {
+	mg, err := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ap := mg.AllPairsShortest()
+	if !reflect.DeepEqual(ap.Vertices, []string{"A", "B", "C", "D", "E", "F"}) {
+		t.Fatal("vertices should be ordered by index", ap.Vertices)
+	}
+	for _, src := range ap.Vertices {
+		for _, dest := range ap.Vertices {
+			if src == dest {
+				continue
+			}
+			want, wantErr := mg.Shortest(src, dest)
+			got, err := ap.Path(src, dest)
+			if wantErr != nil {
+				testErrors(t, ErrNoPath, err, 0)
+				continue
+			}
+			if err != nil {
+				t.Fatal(src, dest, err)
+			}
+			if got.Distance != want.Distance {
+				t.Errorf("%s->%s distance %d, want %d", src, dest, got.Distance, want.Distance)
+			}
+		}
+	}
+	if got, _ := ap.Path("A", "F"); !reflect.DeepEqual(got.Path, []string{"A", "C", "B", "D", "F"}) {
+		t.Error("wrong path", got.Path)
+	}
+	_, err = ap.Path("A", "Z")
+	testErrors(t, ErrVertexNotFound, err, 0)
+}
+
+func BenchmarkAllPairs(b *testing.B) {
+	for _, nodes := range []int{16, 64, 256} {
+		g := Generate(nodes)
+		b.Run(strconv.Itoa(nodes)+"Nodes/Dijkstra", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.AllPairsDijkstra(4)
+			}
+		})
+		b.Run(strconv.Itoa(nodes)+"Nodes/FloydWarshall", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.FloydWarshall()
+			}
+		})
+	}
+}
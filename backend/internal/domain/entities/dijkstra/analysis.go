@@ -0,0 +1,398 @@
+package dijkstra
+
+import (
+	"cmp"
+	"slices"
+)
+
+// StronglyConnectedComponents returns the strongly connected components of the
+// graph. Vertices in a component are sorted and components are ordered by
+// their smallest vertex.
+func (g Graph) StronglyConnectedComponents() [][]int {
+	// iterative Tarjan
+	index := make([]int, len(g.vertexArcs))
+	lowLink := make([]int, len(g.vertexArcs))
+	onStack := make([]bool, len(g.vertexArcs))
+	for i := range index {
+		index[i] = -1
+	}
+	adjacency := g.sortedAdjacency()
+	var stack []int
+	var components [][]int
+	next := 0
+	type frame struct {
+		vertex int
+		arc    int
+	}
+	for root, v := range g.vertexArcs {
+		if v == nil || index[root] != -1 {
+			continue
+		}
+		callStack := []frame{{root, 0}}
+		index[root], lowLink[root] = next, next
+		next++
+		stack = append(stack, root)
+		onStack[root] = true
+		for len(callStack) > 0 {
+			top := &callStack[len(callStack)-1]
+			if top.arc < len(adjacency[top.vertex]) {
+				to := adjacency[top.vertex][top.arc]
+				top.arc++
+				if index[to] == -1 {
+					index[to], lowLink[to] = next, next
+					next++
+					stack = append(stack, to)
+					onStack[to] = true
+					callStack = append(callStack, frame{to, 0})
+				} else if onStack[to] {
+					lowLink[top.vertex] = min(lowLink[top.vertex], index[to])
+				}
+				continue
+			}
+			vertex := top.vertex
+			callStack = callStack[:len(callStack)-1]
+			if len(callStack) > 0 {
+				parent := callStack[len(callStack)-1].vertex
+				lowLink[parent] = min(lowLink[parent], lowLink[vertex])
+			}
+			if lowLink[vertex] != index[vertex] {
+				continue
+			}
+			var component []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == vertex {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+	sortComponents(components)
+	return components
+}
+
+// WeaklyConnectedComponents returns the connected components of the graph when
+// arc directions are ignored. Vertices in a component are sorted and
+// components are ordered by their smallest vertex.
+func (g Graph) WeaklyConnectedComponents() [][]int {
+	adjacency := g.undirectedAdjacency()
+	visited := make([]bool, len(g.vertexArcs))
+	var components [][]int
+	for root, v := range g.vertexArcs {
+		if v == nil || visited[root] {
+			continue
+		}
+		visited[root] = true
+		component := []int{root}
+		for i := 0; i < len(component); i++ {
+			for _, to := range adjacency[component[i]] {
+				if !visited[to] {
+					visited[to] = true
+					component = append(component, to)
+				}
+			}
+		}
+		slices.Sort(component)
+		components = append(components, component)
+	}
+	sortComponents(components)
+	return components
+}
+
+// ArticulationPoints returns the sorted vertices whose removal increases the
+// amount of weakly connected components
+func (g Graph) ArticulationPoints() []int {
+	points, _ := g.cutVerticesAndBridges()
+	return points
+}
+
+// Bridges returns the arcs, with arc directions ignored, whose removal
+// increases the amount of weakly connected components. Src is always the
+// smaller vertex and bridges are sorted.
+func (g Graph) Bridges() []Arc[int] {
+	_, bridges := g.cutVerticesAndBridges()
+	return bridges
+}
+
+func (g Graph) cutVerticesAndBridges() ([]int, []Arc[int]) {
+	// iterative Hopcroft-Tarjan over the undirected view
+	adjacency := g.undirectedAdjacency()
+	discovery := make([]int, len(g.vertexArcs))
+	low := make([]int, len(g.vertexArcs))
+	for i := range discovery {
+		discovery[i] = -1
+	}
+	isPoint := make([]bool, len(g.vertexArcs))
+	var bridges []Arc[int]
+	next := 0
+	type frame struct {
+		vertex   int
+		parent   int
+		arc      int
+		children int
+	}
+	for root, v := range g.vertexArcs {
+		if v == nil || discovery[root] != -1 {
+			continue
+		}
+		discovery[root], low[root] = next, next
+		next++
+		callStack := []frame{{root, -1, 0, 0}}
+		for len(callStack) > 0 {
+			top := &callStack[len(callStack)-1]
+			if top.arc < len(adjacency[top.vertex]) {
+				to := adjacency[top.vertex][top.arc]
+				top.arc++
+				if to == top.parent {
+					continue
+				}
+				if discovery[to] == -1 {
+					top.children++
+					discovery[to], low[to] = next, next
+					next++
+					callStack = append(callStack, frame{to, top.vertex, 0, 0})
+				} else {
+					low[top.vertex] = min(low[top.vertex], discovery[to])
+				}
+				continue
+			}
+			child := *top
+			callStack = callStack[:len(callStack)-1]
+			if child.parent == -1 {
+				if child.children > 1 {
+					isPoint[child.vertex] = true
+				}
+				continue
+			}
+			low[child.parent] = min(low[child.parent], low[child.vertex])
+			if low[child.vertex] > discovery[child.parent] {
+				bridges = append(bridges, Arc[int]{min(child.parent, child.vertex), max(child.parent, child.vertex)})
+			}
+			if callStack[len(callStack)-1].parent != -1 && low[child.vertex] >= discovery[child.parent] {
+				isPoint[child.parent] = true
+			}
+		}
+	}
+	var points []int
+	for v, ok := range isPoint {
+		if ok {
+			points = append(points, v)
+		}
+	}
+	slices.SortFunc(bridges, func(a, b Arc[int]) int {
+		return cmp.Or(cmp.Compare(a.Src, b.Src), cmp.Compare(a.Dest, b.Dest))
+	})
+	return points, bridges
+}
+
+// Eccentricity returns the greatest shortest distance from src to any other
+// vertex, it fails if a vertex can not be reached from src
+func (g Graph) Eccentricity(src int) (uint64, error) {
+	if err := g.vertexValid(src); err != nil {
+		return 0, err
+	}
+	tree := g.shortestFrom(src)
+	var eccentricity uint64
+	for dest, v := range g.vertexArcs {
+		if v == nil {
+			continue
+		}
+		if tree.distances[dest] == Infinity {
+			return 0, newErrNoPath(src, dest)
+		}
+		eccentricity = max(eccentricity, tree.distances[dest])
+	}
+	return eccentricity, nil
+}
+
+// Eccentricities returns the eccentricity of every vertex that reaches every
+// other vertex, each computed once. It fails with the vertices it could find
+// when the graph is not strongly connected.
+func (g Graph) Eccentricities() (map[int]uint64, error) {
+	eccentricities := make(map[int]uint64)
+	var errNotConnected error
+	for src, v := range g.vertexArcs {
+		if v == nil {
+			continue
+		}
+		eccentricity, err := g.Eccentricity(src)
+		if err != nil {
+			if errNotConnected == nil {
+				errNotConnected = newErrNotStronglyConnected(err)
+			}
+			continue
+		}
+		eccentricities[src] = eccentricity
+	}
+	return eccentricities, errNotConnected
+}
+
+// Radius returns the smallest eccentricity of the graph, it fails if the graph
+// is not strongly connected
+func (g Graph) Radius() (uint64, error) {
+	eccentricities, err := g.Eccentricities()
+	if err != nil {
+		return 0, err
+	}
+	radius, _ := EccentricityBounds(eccentricities)
+	return radius, nil
+}
+
+// Diameter returns the greatest eccentricity of the graph, it fails if the
+// graph is not strongly connected
+func (g Graph) Diameter() (uint64, error) {
+	eccentricities, err := g.Eccentricities()
+	if err != nil {
+		return 0, err
+	}
+	_, diameter := EccentricityBounds(eccentricities)
+	return diameter, nil
+}
+
+// EccentricityBounds returns the radius and the diameter from the
+// eccentricities of every vertex, both 0 when there are none
+func EccentricityBounds[T comparable](eccentricities map[T]uint64) (radius, diameter uint64) {
+	first := true
+	for _, eccentricity := range eccentricities {
+		if first {
+			radius, diameter = eccentricity, eccentricity
+			first = false
+			continue
+		}
+		radius = min(radius, eccentricity)
+		diameter = max(diameter, eccentricity)
+	}
+	return radius, diameter
+}
+
+// sortedAdjacency returns the arc destinations of every vertex, sorted so
+// searches over the graph are deterministic
+func (g Graph) sortedAdjacency() [][]int {
+	adjacency := make([][]int, len(g.vertexArcs))
+	for from, arcs := range g.vertexArcs {
+		for to := range arcs {
+			adjacency[from] = append(adjacency[from], to)
+		}
+		slices.Sort(adjacency[from])
+	}
+	return adjacency
+}
+
+// undirectedAdjacency returns the sorted neighbours of every vertex when arc
+// directions are ignored, arcs in both directions count as a single edge
+func (g Graph) undirectedAdjacency() [][]int {
+	adjacency := make([][]int, len(g.vertexArcs))
+	for from, arcs := range g.vertexArcs {
+		for to := range arcs {
+			if to == from {
+				continue
+			}
+			adjacency[from] = append(adjacency[from], to)
+			if _, ok := g.vertexArcs[to][from]; !ok {
+				adjacency[to] = append(adjacency[to], from)
+			}
+		}
+	}
+	for i := range adjacency {
+		slices.Sort(adjacency[i])
+	}
+	return adjacency
+}
+
+func sortComponents(components [][]int) {
+	slices.SortFunc(components, func(a, b []int) int {
+		return cmp.Compare(a[0], b[0])
+	})
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the
+// graph, ordered like the components of Graph
+func (mg MappedGraph[T]) StronglyConnectedComponents() [][]T {
+	return mg.toMappedComponents(mg.graph.StronglyConnectedComponents())
+}
+
+// WeaklyConnectedComponents returns the connected components of the graph when
+// arc directions are ignored
+func (mg MappedGraph[T]) WeaklyConnectedComponents() [][]T {
+	return mg.toMappedComponents(mg.graph.WeaklyConnectedComponents())
+}
+
+// ArticulationPoints returns the vertices whose removal increases the amount
+// of weakly connected components
+func (mg MappedGraph[T]) ArticulationPoints() []T {
+	vertices := mg.indexedVertices()
+	var points []T
+	for _, v := range mg.graph.ArticulationPoints() {
+		points = append(points, vertices[v])
+	}
+	return points
+}
+
+// Bridges returns the arcs, with arc directions ignored, whose removal
+// increases the amount of weakly connected components
+func (mg MappedGraph[T]) Bridges() []Arc[T] {
+	vertices := mg.indexedVertices()
+	var bridges []Arc[T]
+	for _, arc := range mg.graph.Bridges() {
+		bridges = append(bridges, Arc[T]{vertices[arc.Src], vertices[arc.Dest]})
+	}
+	return bridges
+}
+
+// Eccentricity returns the greatest shortest distance from src to any other
+// vertex
+func (mg MappedGraph[T]) Eccentricity(src T) (uint64, error) {
+	id, err := mg.getMap(src)
+	if err != nil {
+		return 0, err
+	}
+	return mg.graph.Eccentricity(id)
+}
+
+// Eccentricities returns the eccentricity of every vertex that reaches every
+// other vertex, each computed once
+func (mg MappedGraph[T]) Eccentricities() (map[T]uint64, error) {
+	vertices := mg.indexedVertices()
+	eccentricities, err := mg.graph.Eccentricities()
+	mapped := make(map[T]uint64, len(eccentricities))
+	for v, eccentricity := range eccentricities {
+		mapped[vertices[v]] = eccentricity
+	}
+	return mapped, err
+}
+
+// Radius returns the smallest eccentricity of the graph
+func (mg MappedGraph[T]) Radius() (uint64, error) {
+	return mg.graph.Radius()
+}
+
+// Diameter returns the greatest eccentricity of the graph
+func (mg MappedGraph[T]) Diameter() (uint64, error) {
+	return mg.graph.Diameter()
+}
+
+func (mg MappedGraph[T]) toMappedComponents(components [][]int) [][]T {
+	vertices := mg.indexedVertices()
+	mapped := make([][]T, len(components))
+	for i, component := range components {
+		mapped[i] = make([]T, len(component))
+		for j, v := range component {
+			mapped[i][j] = vertices[v]
+		}
+	}
+	return mapped
+}
+
+// indexedVertices returns the mapped vertices indexed by their graph index
+func (mg MappedGraph[T]) indexedVertices() []T {
+	vertices := make([]T, len(mg.graph.vertexArcs))
+	for k, v := range mg.mapping {
+		vertices[v] = k
+	}
+	return vertices
+}
@@ -0,0 +1,158 @@
+package dijkstra
+
+import (
+	"reflect"
+	"testing"
+)
+
+// analysisTestGraph is two triangles joined by the bridge 2-3, with a tail 5-6
+// that can only be left, not entered
+func analysisTestGraph() Graph {
+	return Graph{
+		[]map[int]uint64{
+			{1: 1, 2: 1},
+			{0: 1, 2: 1},
+			{0: 1, 1: 1, 3: 5},
+			{2: 5, 4: 1, 5: 1},
+			{3: 1, 5: 1},
+			{3: 1, 4: 1},
+			{5: 2},
+		},
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := analysisTestGraph()
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0, 1, 2, 3, 4, 5}, {6}}) {
+		t.Error("wrong strongly connected components", got)
+	}
+	if got := g.WeaklyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0, 1, 2, 3, 4, 5, 6}}) {
+		t.Error("wrong weakly connected components", got)
+	}
+	g.RemoveArc(2, 3)
+	g.RemoveArc(3, 2)
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}) {
+		t.Error("wrong strongly connected components after split", got)
+	}
+	if got := g.WeaklyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0, 1, 2}, {3, 4, 5, 6}}) {
+		t.Error("wrong weakly connected components after split", got)
+	}
+	g.RemoveVertexAndArcs(6)
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0, 1, 2}, {3, 4, 5}}) {
+		t.Error("removed vertices should not be components", got)
+	}
+	chain := Graph{[]map[int]uint64{{1: 1}, {2: 1}, {3: 1}, {}}}
+	if got := chain.StronglyConnectedComponents(); !reflect.DeepEqual(got, [][]int{{0}, {1}, {2}, {3}}) {
+		t.Error("wrong strongly connected components of chain", got)
+	}
+}
+
+func TestArticulationPointsAndBridges(t *testing.T) {
+	g := analysisTestGraph()
+	if got := g.ArticulationPoints(); !reflect.DeepEqual(got, []int{2, 3, 5}) {
+		t.Error("wrong articulation points", got)
+	}
+	if got := g.Bridges(); !reflect.DeepEqual(got, []Arc[int]{{2, 3}, {5, 6}}) {
+		t.Error("wrong bridges", got)
+	}
+	cycle := Graph{[]map[int]uint64{{1: 1}, {2: 1}, {3: 1}, {0: 1}}}
+	if got := cycle.ArticulationPoints(); len(got) != 0 {
+		t.Error("cycle should have no articulation points", got)
+	}
+	if got := cycle.Bridges(); len(got) != 0 {
+		t.Error("cycle should have no bridges", got)
+	}
+	star := Graph{[]map[int]uint64{{1: 1, 2: 1, 3: 1}, {}, {}, {}}}
+	if got := star.ArticulationPoints(); !reflect.DeepEqual(got, []int{0}) {
+		t.Error("wrong articulation points of star", got)
+	}
+	if got := star.Bridges(); !reflect.DeepEqual(got, []Arc[int]{{0, 1}, {0, 2}, {0, 3}}) {
+		t.Error("wrong bridges of star", got)
+	}
+}
+
+func TestEccentricity(t *testing.T) {
+	g := analysisTestGraph()
+	_, err := g.Eccentricity(0)
+	testErrors(t, ErrNoPath, err, 0)
+	_, err = g.Diameter()
+	testErrors(t, ErrNotStronglyConnected, err, 0)
+	g.RemoveVertexAndArcs(6)
+	tests := []struct {
+		vertex int
+		want   uint64
+	}{{0, 7}, {1, 7}, {2, 6}, {3, 6}, {4, 7}, {5, 7}}
+	for i, test := range tests {
+		got, err := g.Eccentricity(test.vertex)
+		testErrors(t, nil, err, i)
+		if got != test.want {
+			t.Errorf("eccentricity of %d is %d, want %d", test.vertex, got, test.want)
+		}
+	}
+	if radius, err := g.Radius(); err != nil || radius != 6 {
+		t.Error("wrong radius", radius, err)
+	}
+	if diameter, err := g.Diameter(); err != nil || diameter != 7 {
+		t.Error("wrong diameter", diameter, err)
+	}
+}
+
+func TestEccentricities(t *testing.T) {
+	g := analysisTestGraph()
+	partial, err := g.Eccentricities()
+	testErrors(t, ErrNotStronglyConnected, err, 0)
+	for v, eccentricity := range partial {
+		if want, err := g.Eccentricity(v); err != nil || eccentricity != want {
+			t.Errorf("eccentricity of %d is %d, want %d (%v)", v, eccentricity, want, err)
+		}
+	}
+	g.RemoveVertexAndArcs(6)
+	eccentricities, err := g.Eccentricities()
+	testErrors(t, nil, err, 1)
+	want := map[int]uint64{0: 7, 1: 7, 2: 6, 3: 6, 4: 7, 5: 7}
+	if !reflect.DeepEqual(eccentricities, want) {
+		t.Error("wrong eccentricities", eccentricities, want)
+	}
+	if radius, diameter := EccentricityBounds(eccentricities); radius != 6 || diameter != 7 {
+		t.Error("wrong bounds", radius, diameter)
+	}
+	if radius, diameter := EccentricityBounds(map[int]uint64{}); radius != 0 || diameter != 0 {
+		t.Error("bounds of no vertex should be 0", radius, diameter)
+	}
+}
+
+func TestAnalysisMapped(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	for _, v := range []string{"A", "B", "C", "D"} {
+		mg.AddEmptyVertex(v)
+	}
+	mg.AddArc("A", "B", 1)
+	mg.AddArc("B", "A", 1)
+	mg.AddArc("B", "C", 2)
+	mg.AddArc("C", "B", 2)
+	if got := mg.StronglyConnectedComponents(); !reflect.DeepEqual(got, [][]string{{"A", "B", "C"}, {"D"}}) {
+		t.Error("wrong strongly connected components", got)
+	}
+	if got := mg.WeaklyConnectedComponents(); !reflect.DeepEqual(got, [][]string{{"A", "B", "C"}, {"D"}}) {
+		t.Error("wrong weakly connected components", got)
+	}
+	if got := mg.ArticulationPoints(); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Error("wrong articulation points", got)
+	}
+	if got := mg.Bridges(); !reflect.DeepEqual(got, []Arc[string]{{"A", "B"}, {"B", "C"}}) {
+		t.Error("wrong bridges", got)
+	}
+	mg.RemoveVertex("D")
+	if got, err := mg.Eccentricity("A"); err != nil || got != 3 {
+		t.Error("wrong eccentricity", got, err)
+	}
+	if got, err := mg.Diameter(); err != nil || got != 3 {
+		t.Error("wrong diameter", got, err)
+	}
+	if got, err := mg.Radius(); err != nil || got != 2 {
+		t.Error("wrong radius", got, err)
+	}
+	if got, err := mg.Eccentricities(); err != nil || !reflect.DeepEqual(got, map[string]uint64{"A": 3, "B": 2, "C": 3}) {
+		t.Error("wrong eccentricities", got, err)
+	}
+}
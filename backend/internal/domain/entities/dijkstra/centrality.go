@@ -0,0 +1,157 @@
+package dijkstra
+
+// BetweennessCentrality returns, for every vertex, the fraction of shortest
+// paths between other pairs of vertices that go through it (Brandes). Paths
+// are weighted by the arc distances and values are normalised by
+// (n-1)(n-2), removed vertices get 0.
+func (g Graph) BetweennessCentrality() []float64 {
+	centrality := make([]float64, len(g.vertexArcs))
+	vertices := 0
+	for src, v := range g.vertexArcs {
+		if v == nil {
+			continue
+		}
+		vertices++
+		distances := make([]uint64, len(g.vertexArcs))
+		paths := make([]float64, len(g.vertexArcs))
+		dependency := make([]float64, len(g.vertexArcs))
+		predecessors := make([][]int, len(g.vertexArcs))
+		settled := make([]bool, len(g.vertexArcs))
+		for i := range distances {
+			distances[i] = Infinity
+		}
+		distances[src] = 0
+		paths[src] = 1
+		var order []int
+		visiting := g.getList(listShortPQ)
+		visiting.PushOrdered(currentDistance{src, 0})
+		for visiting.Len() > 0 {
+			current := visiting.PopOrdered()
+			if settled[current.id] || current.distance > distances[current.id] {
+				continue
+			}
+			settled[current.id] = true
+			order = append(order, current.id)
+			for to, dist := range g.vertexArcs[current.id] {
+				distance := current.distance + dist
+				if distance < current.distance || settled[to] {
+					continue
+				}
+				switch {
+				case distance < distances[to]:
+					distances[to] = distance
+					paths[to] = paths[current.id]
+					predecessors[to] = []int{current.id}
+					visiting.PushOrdered(currentDistance{to, distance})
+				case distance == distances[to]:
+					paths[to] += paths[current.id]
+					predecessors[to] = append(predecessors[to], current.id)
+				}
+			}
+		}
+		for i := len(order) - 1; i >= 0; i-- {
+			w := order[i]
+			for _, v := range predecessors[w] {
+				dependency[v] += paths[v] / paths[w] * (1 + dependency[w])
+			}
+			if w != src {
+				centrality[w] += dependency[w]
+			}
+		}
+	}
+	if vertices > 2 {
+		scale := float64((vertices - 1) * (vertices - 2))
+		for i := range centrality {
+			centrality[i] /= scale
+		}
+	}
+	return centrality
+}
+
+// ClosenessCentrality returns, for every vertex, the inverse of the average
+// distance to the vertices it reaches, scaled by the fraction of vertices it
+// reaches (Wasserman and Faust) so disconnected graphs can be compared.
+// Vertices that reach nothing and removed vertices get 0.
+func (g Graph) ClosenessCentrality() []float64 {
+	centrality := make([]float64, len(g.vertexArcs))
+	vertices := 0
+	for _, v := range g.vertexArcs {
+		if v != nil {
+			vertices++
+		}
+	}
+	if vertices < 2 {
+		return centrality
+	}
+	for src, v := range g.vertexArcs {
+		if v == nil {
+			continue
+		}
+		tree := g.shortestFrom(src)
+		var total float64
+		reached := 0
+		for dest, distance := range tree.distances {
+			if dest == src || distance == Infinity {
+				continue
+			}
+			total += float64(distance)
+			reached++
+		}
+		if reached == 0 || total == 0 {
+			continue
+		}
+		centrality[src] = float64(reached) / total * float64(reached) / float64(vertices-1)
+	}
+	return centrality
+}
+
+// DegreeCentrality returns, for every vertex, the amount of arcs going in and
+// out of it divided by n-1, removed vertices get 0
+func (g Graph) DegreeCentrality() []float64 {
+	centrality := make([]float64, len(g.vertexArcs))
+	vertices := 0
+	for from, arcs := range g.vertexArcs {
+		if arcs == nil {
+			continue
+		}
+		vertices++
+		for to := range arcs {
+			if to == from {
+				continue
+			}
+			centrality[from]++
+			centrality[to]++
+		}
+	}
+	if vertices > 1 {
+		for i := range centrality {
+			centrality[i] /= float64(vertices - 1)
+		}
+	}
+	return centrality
+}
+
+// BetweennessCentrality returns the betweenness centrality of every vertex
+func (mg MappedGraph[T]) BetweennessCentrality() map[T]float64 {
+	return mg.toMappedCentrality(mg.graph.BetweennessCentrality())
+}
+
+// ClosenessCentrality returns the closeness centrality of every vertex
+func (mg MappedGraph[T]) ClosenessCentrality() map[T]float64 {
+	return mg.toMappedCentrality(mg.graph.ClosenessCentrality())
+}
+
+// DegreeCentrality returns the degree centrality of every vertex
+func (mg MappedGraph[T]) DegreeCentrality() map[T]float64 {
+	return mg.toMappedCentrality(mg.graph.DegreeCentrality())
+}
+
+func (mg MappedGraph[T]) toMappedCentrality(centrality []float64) map[T]float64 {
+	mapped := make(map[T]float64, len(mg.mapping))
+	for k, v := range mg.mapping {
+		if mg.graph.vertexValid(v) == nil {
+			mapped[k] = centrality[v]
+		}
+	}
+	return mapped
+}
@@ -0,0 +1,59 @@
+package dijkstra
+
+import (
+	"math"
+	"testing"
+)
+
+func assertCentrality(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("%s: vertex %d got %f, want %f", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestCentrality(t *testing.T) {
+	path := Graph{[]map[int]uint64{{1: 1}, {0: 1, 2: 1}, {1: 1, 3: 1}, {2: 1}}}
+	assertCentrality(t, "BetweennessPath", path.BetweennessCentrality(), []float64{0, 4.0 / 6, 4.0 / 6, 0})
+	assertCentrality(t, "ClosenessPath", path.ClosenessCentrality(), []float64{0.5, 0.75, 0.75, 0.5})
+	assertCentrality(t, "DegreePath", path.DegreeCentrality(), []float64{2.0 / 3, 4.0 / 3, 4.0 / 3, 2.0 / 3})
+
+	diamond := Graph{[]map[int]uint64{{1: 1, 2: 1}, {3: 1}, {3: 1}, {}}}
+	assertCentrality(t, "BetweennessDiamond", diamond.BetweennessCentrality(), []float64{0, 0.5 / 6, 0.5 / 6, 0})
+	// 0 reaches all three, 1 and 2 reach only 3, 3 reaches nothing
+	assertCentrality(t, "ClosenessDiamond", diamond.ClosenessCentrality(), []float64{3.0 / 4, 1.0 / 3, 1.0 / 3, 0})
+
+	weighted := Graph{[]map[int]uint64{{1: 1, 2: 3}, {2: 1}, {}}}
+	assertCentrality(t, "BetweennessWeighted", weighted.BetweennessCentrality(), []float64{0, 0.5, 0})
+	weighted.vertexArcs[0][2] = 1
+	assertCentrality(t, "BetweennessWeightedShortcut", weighted.BetweennessCentrality(), []float64{0, 0, 0})
+
+	removed := Graph{[]map[int]uint64{{1: 1}, {0: 1, 2: 1}, {1: 1}}}
+	removed.RemoveVertexAndArcs(2)
+	assertCentrality(t, "DegreeRemoved", removed.DegreeCentrality(), []float64{2, 2, 0})
+}
+
+func TestCentralityMapped(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	for _, v := range []string{"A", "B", "C"} {
+		mg.AddEmptyVertex(v)
+	}
+	mg.AddArc("A", "B", 1)
+	mg.AddArc("B", "C", 1)
+	mg.AddArc("A", "C", 5)
+	betweenness := mg.BetweennessCentrality()
+	if len(betweenness) != 3 || betweenness["B"] != 0.5 || betweenness["A"] != 0 {
+		t.Error("wrong betweenness", betweenness)
+	}
+	if degree := mg.DegreeCentrality(); degree["B"] != 1 || degree["A"] != 1 {
+		t.Error("wrong degree", degree)
+	}
+	if closeness := mg.ClosenessCentrality(); closeness["C"] != 0 || closeness["B"] != 0.5 {
+		t.Error("wrong closeness", closeness)
+	}
+}
@@ -0,0 +1,72 @@
+package dijkstra
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentGraph shares a mapped graph between goroutines. Writers work on a
+// copy of the current graph and publish it as a new version once they are
+// done (copy on write), so readers never wait for writers and always see a
+// consistent graph.
+type ConcurrentGraph[T comparable] struct {
+	// mu serialises the writers, readers do not use it
+	mu      sync.Mutex
+	current atomic.Pointer[Snapshot[T]]
+}
+
+// Snapshot is a version of a ConcurrentGraph, it never changes after being
+// published
+type Snapshot[T comparable] struct {
+	Version uint64
+	graph   MappedGraph[T]
+}
+
+// NewConcurrentGraph creates a concurrent graph holding an empty graph at
+// version 0
+func NewConcurrentGraph[T comparable]() *ConcurrentGraph[T] {
+	cg := &ConcurrentGraph[T]{}
+	cg.current.Store(&Snapshot[T]{graph: NewMappedGraph[T]()})
+	return cg
+}
+
+// Snapshot returns the latest published version of the graph
+func (cg *ConcurrentGraph[T]) Snapshot() Snapshot[T] {
+	return *cg.current.Load()
+}
+
+// Version returns the number of the latest published version
+func (cg *ConcurrentGraph[T]) Version() uint64 {
+	return cg.current.Load().Version
+}
+
+// Update applies fn to a copy of the latest graph and publishes the copy as
+// the next version. If fn fails the copy is dropped and the version does not
+// change. Updates run one at a time, fn must not keep the graph it receives.
+func (cg *ConcurrentGraph[T]) Update(fn func(graph *MappedGraph[T]) error) (uint64, error) {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	latest := cg.current.Load()
+	graph := latest.graph.Clone()
+	if err := fn(&graph); err != nil {
+		return latest.Version, err
+	}
+	cg.current.Store(&Snapshot[T]{Version: latest.Version + 1, graph: graph})
+	return latest.Version + 1, nil
+}
+
+// Replace publishes a copy of graph as the next version
+func (cg *ConcurrentGraph[T]) Replace(graph MappedGraph[T]) uint64 {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	version := cg.current.Load().Version + 1
+	cg.current.Store(&Snapshot[T]{Version: version, graph: graph.Clone()})
+	return version
+}
+
+// Graph returns the graph of the snapshot. It is shared with every other
+// reader of the same version, so it must only be queried, use Clone to get a
+// copy that can be modified.
+func (s Snapshot[T]) Graph() MappedGraph[T] {
+	return s.graph
+}
@@ -0,0 +1,176 @@
+package dijkstra
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	g := Generate(20)
+	g.RemoveVertexAndArcs(3)
+	clone := g.Clone()
+	assertGraphsEqual(t, clone, g, 0)
+	clone.AddArc(0, 1, 999)
+	clone.RemoveVertexAndArcs(5)
+	if dist, _ := g.GetArc(0, 1); dist == 999 {
+		t.Error("clone shares arcs with the original")
+	}
+	if _, err := g.GetVertexArcs(5); err != nil {
+		t.Error("clone shares vertices with the original", err)
+	}
+
+	mg, err := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mappedClone := mg.Clone()
+	if err := mappedClone.AddEmptyVertex("new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.AddEmptyVertex("new"); err != nil {
+		t.Error("clone shares the mapping with the original", err)
+	}
+}
+
+func TestMappedRemoveVertexAgain(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	mg.AddEmptyVertex("A")
+	mg.AddEmptyVertex("B")
+	mg.AddArc("A", "B", 1)
+	if err := mg.RemoveVertexAndArcs("B"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mg.GetArc("A", "B"); !errors.Is(err, ErrVertexNotFound) {
+		t.Error("removed vertex still mapped", err)
+	}
+	if err := mg.AddEmptyVertex("C"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.AddEmptyVertex("B"); err != nil {
+		t.Error("removed vertex can not be added again", err)
+	}
+	mg.AddArc("A", "C", 2)
+	mg.AddArc("A", "B", 3)
+	if best, err := mg.Shortest("A", "C"); err != nil || best.Distance != 2 {
+		t.Error("wrong path after adding the vertex again", best, err)
+	}
+}
+
+func TestConcurrentGraphUpdate(t *testing.T) {
+	cg := NewConcurrentGraph[string]()
+	if cg.Version() != 0 {
+		t.Error("new graph should be at version 0", cg.Version())
+	}
+	version, err := cg.Update(func(mg *MappedGraph[string]) error {
+		mg.AddEmptyVertex("A")
+		mg.AddEmptyVertex("B")
+		return mg.AddArc("A", "B", 5)
+	})
+	if err != nil || version != 1 {
+		t.Fatal("wrong update", version, err)
+	}
+	before := cg.Snapshot()
+
+	version, err = cg.Update(func(mg *MappedGraph[string]) error {
+		return mg.AddArc("A", "B", 1)
+	})
+	if err != nil || version != 2 {
+		t.Fatal("wrong update", version, err)
+	}
+	if dist, _ := before.Graph().GetArc("A", "B"); dist != 5 {
+		t.Error("update changed an older snapshot", dist)
+	}
+	if dist, _ := cg.Snapshot().Graph().GetArc("A", "B"); dist != 1 {
+		t.Error("update not published", dist)
+	}
+
+	failure := errors.New("failure")
+	version, err = cg.Update(func(mg *MappedGraph[string]) error {
+		mg.RemoveVertexAndArcs("A")
+		return failure
+	})
+	if !errors.Is(err, failure) || version != 2 || cg.Version() != 2 {
+		t.Error("failed update should keep the version", version, err)
+	}
+	if _, err := cg.Snapshot().Graph().GetArc("A", "B"); err != nil {
+		t.Error("failed update was published", err)
+	}
+
+	replacement := NewMappedGraph[string]()
+	replacement.AddEmptyVertex("C")
+	if version := cg.Replace(replacement); version != 3 {
+		t.Error("wrong replace version", version)
+	}
+	replacement.AddEmptyVertex("D")
+	graph := cg.Snapshot().Graph()
+	if _, err := graph.GetVertexArcs("D"); err == nil {
+		t.Error("replace shares the graph with the caller")
+	}
+}
+
+func TestConcurrentGraphReadersAndWriters(t *testing.T) {
+	const writers, updates, readers = 4, 50, 8
+	cg := NewConcurrentGraph[string]()
+	cg.Update(func(mg *MappedGraph[string]) error {
+		return mg.AddEmptyVertex("root")
+	})
+
+	wg := sync.WaitGroup{}
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range updates {
+				label := strconv.Itoa(w) + "-" + strconv.Itoa(i)
+				cg.Update(func(mg *MappedGraph[string]) error {
+					mg.AddEmptyVertex(label)
+					mg.AddArc("root", label, uint64(i+1))
+					return mg.AddArc(label, "root", uint64(i+1))
+				})
+			}
+		}()
+	}
+	errs := make(chan error, readers)
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for range updates {
+				snapshot := cg.Snapshot()
+				if snapshot.Version < last {
+					errs <- errors.New("version went back")
+					return
+				}
+				last = snapshot.Version
+				graph := snapshot.Graph()
+				arcs, err := graph.GetVertexArcs("root")
+				if err != nil {
+					errs <- err
+					return
+				}
+				// every published version added one vertex with both arcs
+				if uint64(len(arcs)) != snapshot.Version-1 {
+					errs <- errors.New("inconsistent snapshot " + strconv.Itoa(len(arcs)))
+					return
+				}
+				for label := range arcs {
+					if _, err := graph.GetArc(label, "root"); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if cg.Version() != writers*updates+1 {
+		t.Error("wrong final version", cg.Version())
+	}
+}
@@ -0,0 +1,220 @@
+package dijkstra
+
+import "math"
+
+// Arc identifies a directed arc from Src to Dest
+type Arc[T comparable] struct {
+	Src  T
+	Dest T
+}
+
+// Constraints restricts the paths considered by ShortestConstrained. The zero
+// value does not restrict anything.
+type Constraints[T comparable] struct {
+	// ExcludeVertices are never visited, a path can not start or end on them
+	ExcludeVertices []T
+	// ExcludeArcs are never traversed
+	ExcludeArcs []Arc[T]
+	// Waypoints must be visited in the given order between src and dest
+	Waypoints []T
+	// MaxHops is the maximum amount of arcs in the path, 0 means no limit
+	MaxHops int
+	// MaxCost is the maximum distance of the path, 0 means no limit
+	MaxCost uint64
+}
+
+// IsZero reports if the constraints do not restrict anything
+func (c Constraints[T]) IsZero() bool {
+	return len(c.ExcludeVertices) == 0 &&
+		len(c.ExcludeArcs) == 0 &&
+		len(c.Waypoints) == 0 &&
+		c.MaxHops == 0 &&
+		c.MaxCost == 0
+}
+
+// constrainedState is a vertex together with the amount of waypoints already
+// visited and the amount of hops used to get there
+type constrainedState struct {
+	vertex    int
+	waypoints int
+	hops      int
+}
+
+// ShortestConstrained calculates the shortest path from src to dest that
+// satisfies the constraints. Waypoints may force the path to visit a vertex
+// more than once, e.g. to go to a waypoint and come back.
+func (g Graph) ShortestConstrained(src, dest int, c Constraints[int]) (BestPath[int], error) {
+	if err := g.vertexValid(src); err != nil {
+		return BestPath[int]{}, err
+	}
+	if err := g.vertexValid(dest); err != nil {
+		return BestPath[int]{}, err
+	}
+	if c.MaxHops < 0 {
+		return BestPath[int]{}, newErrConstraintsNotValid("max hops is negative")
+	}
+	excluded := make([]bool, len(g.vertexArcs))
+	for _, v := range c.ExcludeVertices {
+		if err := g.vertexValid(v); err != nil {
+			return BestPath[int]{}, err
+		}
+		excluded[v] = true
+	}
+	excludedArcs := make(map[Arc[int]]struct{}, len(c.ExcludeArcs))
+	for _, arc := range c.ExcludeArcs {
+		if err := g.vertexValid(arc.Src); err != nil {
+			return BestPath[int]{}, err
+		}
+		if err := g.vertexValid(arc.Dest); err != nil {
+			return BestPath[int]{}, err
+		}
+		excludedArcs[arc] = struct{}{}
+	}
+	for _, v := range c.Waypoints {
+		if err := g.vertexValid(v); err != nil {
+			return BestPath[int]{}, err
+		}
+		if excluded[v] {
+			return BestPath[int]{}, newErrConstraintsNotValid("waypoint is excluded")
+		}
+	}
+	if excluded[src] || excluded[dest] {
+		return BestPath[int]{}, newErrNoPath(src, dest)
+	}
+
+	// every vertex is expanded into one state per (waypoints visited, hops
+	// used), hops are only tracked if there is a limit on them
+	vertices := len(g.vertexArcs)
+	hopLayers := 1
+	if c.MaxHops > 0 {
+		hopLayers = c.MaxHops + 1
+	}
+	waypointLayers := len(c.Waypoints) + 1
+	stateID := func(s constrainedState) int {
+		return (s.waypoints*hopLayers+s.hops)*vertices + s.vertex
+	}
+	stateOf := func(id int) constrainedState {
+		return constrainedState{
+			vertex:    id % vertices,
+			hops:      (id / vertices) % hopLayers,
+			waypoints: id / vertices / hopLayers,
+		}
+	}
+	nextWaypoint := func(visited, vertex int) int {
+		for visited < len(c.Waypoints) && c.Waypoints[visited] == vertex {
+			visited++
+		}
+		return visited
+	}
+
+	distances := make([]uint64, vertices*hopLayers*waypointLayers)
+	previous := make([]int, len(distances))
+	for i := range distances {
+		distances[i] = math.MaxUint64
+		previous[i] = -1
+	}
+	start := stateID(constrainedState{vertex: src, waypoints: nextWaypoint(0, src)})
+	distances[start] = 0
+	visiting := g.getList(listShortPQ)
+	visiting.PushOrdered(currentDistance{start, 0})
+	found := -1
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		if current.distance > distances[current.id] {
+			continue
+		}
+		state := stateOf(current.id)
+		if state.vertex == dest && state.waypoints == len(c.Waypoints) {
+			found = current.id
+			break
+		}
+		if c.MaxHops > 0 && state.hops == c.MaxHops {
+			continue
+		}
+		for to, dist := range g.vertexArcs[state.vertex] {
+			if excluded[to] {
+				continue
+			}
+			if _, ok := excludedArcs[Arc[int]{state.vertex, to}]; ok {
+				continue
+			}
+			distance := current.distance + dist
+			if distance < current.distance {
+				// overflow
+				continue
+			}
+			if c.MaxCost > 0 && distance > c.MaxCost {
+				continue
+			}
+			next := constrainedState{
+				vertex:    to,
+				waypoints: nextWaypoint(state.waypoints, to),
+			}
+			if c.MaxHops > 0 {
+				next.hops = state.hops + 1
+			}
+			id := stateID(next)
+			if distance < distances[id] {
+				distances[id] = distance
+				previous[id] = current.id
+				visiting.PushOrdered(currentDistance{id, distance})
+			}
+		}
+	}
+	if found == -1 {
+		return BestPath[int]{}, newErrNoPath(src, dest)
+	}
+	var path []int
+	for id := found; id != -1; id = previous[id] {
+		path = append(path, stateOf(id).vertex)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return BestPath[int]{distances[found], path}, nil
+}
+
+// ShortestConstrained calculates the shortest path from src to dest that
+// satisfies the constraints
+func (mg MappedGraph[T]) ShortestConstrained(src, dest T, c Constraints[T]) (BestPath[T], error) {
+	srcId, destId, err := mg.getMap2(src, dest)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	mapped, err := mg.getMappedConstraints(c)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	bp, err := mg.graph.ShortestConstrained(srcId, destId, mapped)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	return mg.toMappedBestPath(bp)
+}
+
+func (mg MappedGraph[T]) getMappedConstraints(c Constraints[T]) (Constraints[int], error) {
+	mapped := Constraints[int]{
+		ExcludeVertices: make([]int, len(c.ExcludeVertices)),
+		ExcludeArcs:     make([]Arc[int], len(c.ExcludeArcs)),
+		Waypoints:       make([]int, len(c.Waypoints)),
+		MaxHops:         c.MaxHops,
+		MaxCost:         c.MaxCost,
+	}
+	var err error
+	for i, v := range c.ExcludeVertices {
+		if mapped.ExcludeVertices[i], err = mg.getMap(v); err != nil {
+			return mapped, err
+		}
+	}
+	for i, arc := range c.ExcludeArcs {
+		if mapped.ExcludeArcs[i].Src, mapped.ExcludeArcs[i].Dest, err = mg.getMap2(arc.Src, arc.Dest); err != nil {
+			return mapped, err
+		}
+	}
+	for i, v := range c.Waypoints {
+		if mapped.Waypoints[i], err = mg.getMap(v); err != nil {
+			return mapped, err
+		}
+	}
+	return mapped, nil
+}
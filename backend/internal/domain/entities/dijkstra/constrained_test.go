@@ -0,0 +1,102 @@
+package dijkstra
+
+import (
+	"reflect"
+	"testing"
+)
+
+func constrainedTestGraph() Graph {
+	return Graph{
+		[]map[int]uint64{
+			{1: 1, 2: 4, 4: 20},
+			{2: 1, 3: 5},
+			{3: 1, 4: 10},
+			{4: 1},
+			{2: 1},
+		},
+	}
+}
+
+func TestShortestConstrained(t *testing.T) {
+	tests := []struct {
+		name        string
+		src, dest   int
+		constraints Constraints[int]
+		want        BestPath[int]
+		wantErr     error
+	}{
+		{"None", 0, 4, Constraints[int]{}, BestPath[int]{4, []int{0, 1, 2, 3, 4}}, nil},
+		{"ExcludeVertex", 0, 4, Constraints[int]{ExcludeVertices: []int{2}}, BestPath[int]{7, []int{0, 1, 3, 4}}, nil},
+		{"ExcludeArc", 0, 4, Constraints[int]{ExcludeArcs: []Arc[int]{{1, 2}}}, BestPath[int]{6, []int{0, 2, 3, 4}}, nil},
+		{"MaxHops2", 0, 4, Constraints[int]{MaxHops: 2}, BestPath[int]{14, []int{0, 2, 4}}, nil},
+		{"MaxHops3", 0, 4, Constraints[int]{MaxHops: 3}, BestPath[int]{6, []int{0, 2, 3, 4}}, nil},
+		{"MaxHops1", 0, 4, Constraints[int]{MaxHops: 1}, BestPath[int]{20, []int{0, 4}}, nil},
+		{"Waypoint", 0, 2, Constraints[int]{Waypoints: []int{3}}, BestPath[int]{5, []int{0, 1, 2, 3, 4, 2}}, nil},
+		{"WaypointsOrdered", 0, 4, Constraints[int]{Waypoints: []int{2, 1}}, BestPath[int]{}, ErrNoPath},
+		{"WaypointIsSource", 0, 4, Constraints[int]{Waypoints: []int{0, 3}}, BestPath[int]{4, []int{0, 1, 2, 3, 4}}, nil},
+		{"MaxCostMet", 0, 4, Constraints[int]{MaxCost: 4}, BestPath[int]{4, []int{0, 1, 2, 3, 4}}, nil},
+		{"MaxCostNotMet", 0, 4, Constraints[int]{MaxCost: 3}, BestPath[int]{}, ErrNoPath},
+		{"ExcludeSource", 0, 4, Constraints[int]{ExcludeVertices: []int{0}}, BestPath[int]{}, ErrNoPath},
+		{"ExcludeWaypoint", 0, 4, Constraints[int]{ExcludeVertices: []int{3}, Waypoints: []int{3}}, BestPath[int]{}, ErrConstraintsNotValid},
+		{"NegativeHops", 0, 4, Constraints[int]{MaxHops: -1}, BestPath[int]{}, ErrConstraintsNotValid},
+		{"UnknownVertex", 0, 4, Constraints[int]{ExcludeVertices: []int{9}}, BestPath[int]{}, ErrVertexNotFound},
+		{"Combined", 0, 4, Constraints[int]{ExcludeVertices: []int{1}, Waypoints: []int{2}, MaxHops: 3}, BestPath[int]{6, []int{0, 2, 3, 4}}, nil},
+	}
+	g := constrainedTestGraph()
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := g.ShortestConstrained(test.src, test.dest, test.constraints)
+			testErrors(t, test.wantErr, err, i)
+			if test.wantErr != nil {
+				return
+			}
+			testResults(t, test.want, got, true, i)
+		})
+	}
+}
+
+func TestShortestConstrainedUnconstrained(t *testing.T) {
+	for i, test := range testGraphsCorrect {
+		got, err := test.graph.ShortestConstrained(test.from, test.to, Constraints[int]{})
+		testErrors(t, test.evalErr, err, i)
+		testResults(t, test.shortestSolution, got, true, i)
+	}
+}
+
+func TestShortestConstrainedMapped(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		mg.AddEmptyVertex(v)
+	}
+	mg.AddArc("A", "B", 1)
+	mg.AddArc("B", "C", 1)
+	mg.AddArc("C", "F", 1)
+	mg.AddArc("A", "D", 2)
+	mg.AddArc("D", "E", 2)
+	mg.AddArc("E", "F", 2)
+	mg.AddArc("B", "D", 1)
+
+	got, err := mg.ShortestConstrained("A", "F", Constraints[string]{
+		ExcludeVertices: []string{"C"},
+		Waypoints:       []string{"D"},
+		MaxHops:         4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Distance != 6 || !reflect.DeepEqual(got.Path, []string{"A", "D", "E", "F"}) {
+		t.Fatal("wrong path", got)
+	}
+	got, err = mg.ShortestConstrained("A", "F", Constraints[string]{
+		ExcludeArcs: []Arc[string]{{"A", "D"}},
+		Waypoints:   []string{"D"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Distance != 6 || !reflect.DeepEqual(got.Path, []string{"A", "B", "D", "E", "F"}) {
+		t.Fatal("wrong path", got)
+	}
+	_, err = mg.ShortestConstrained("A", "F", Constraints[string]{ExcludeVertices: []string{"Z"}})
+	testErrors(t, ErrVertexNotFound, err, 0)
+}
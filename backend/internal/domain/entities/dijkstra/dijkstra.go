@@ -93,6 +93,11 @@ func (g Graph) getList(i int) dijkstraList {
 }
 
 func (g Graph) evaluate(src, dest int, shortest bool, listOption int) (BestPath[int], error) {
+	return g.evaluateList(src, dest, shortest, g.getList(listOption))
+}
+
+// evaluateList runs evaluate over the given list, so callers can wrap it
+func (g Graph) evaluateList(src, dest int, shortest bool, visiting dijkstraList) (BestPath[int], error) {
 	if err := g.vertexValid(src); err != nil {
 		return BestPath[int]{}, err
 	}
@@ -119,7 +124,6 @@ func (g Graph) evaluate(src, dest int, shortest bool, listOption int) (BestPath[
 			return currentDistance >= best || currentDistance > storedDistance
 		}
 	}
-	var visiting = g.getList(listOption)
 	distances := make([]uint64, len(g.vertexArcs))
 	bestVerticie := make([]int, len(g.vertexArcs))
 	for i := range bestVerticie {
@@ -136,7 +140,7 @@ func (g Graph) evaluate(src, dest int, shortest bool, listOption int) (BestPath[
 		for to, dist := range g.vertexArcs[current.id] {
 			if better(current.distance+dist, distances[to]) {
 				if bestVerticie[current.id] == to && to != dest {
-					return BestPath[int]{}, newErrLoop(current.id, to)
+					return BestPath[int]{}, g.newErrLoopFrom(src, current.id, to)
 				}
 				distances[to] = current.distance + dist
 				bestVerticie[to] = current.id
@@ -152,15 +156,7 @@ func (g Graph) evaluate(src, dest int, shortest bool, listOption int) (BestPath[
 	if !visitedDest {
 		return BestPath[int]{}, newErrNoPath(src, dest)
 	}
-	var path []int
-	for c := dest; c != src; c = bestVerticie[c] {
-		path = append(path, c)
-	}
-	path = append(path, src)
-	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
-		path[i], path[j] = path[j], path[i]
-	}
-	return BestPath[int]{distances[dest], path}, nil
+	return BestPath[int]{distances[dest], buildPath(bestVerticie, src, dest)}, nil
 }
 
 func (g Graph) evaluateAll(src, dest int, shortest bool, listOption int) (BestPaths[int], error) {
@@ -207,7 +203,7 @@ func (g Graph) evaluateAll(src, dest int, shortest bool, listOption int) (BestPa
 		for to, dist := range g.vertexArcs[current.id] {
 			if better(current.distance+dist, distances[to]) {
 				if bestVerticies[current.id][0] == to && to != dest {
-					return BestPaths[int]{}, newErrLoop(current.id, to)
+					return BestPaths[int]{}, g.newErrLoopFrom(src, current.id, to)
 				}
 				if (current.distance + dist) == distances[to] {
 					bestVerticies[to] = append(bestVerticies[to], current.id)
@@ -233,6 +229,15 @@ func (g Graph) evaluateAll(src, dest int, shortest bool, listOption int) (BestPa
 	}, nil
 }
 
+// newErrLoopFrom returns a CycleError with a cycle reachable from src, the loop
+// between a and b is only reported if no cycle can be found
+func (g Graph) newErrLoopFrom(src, a, b int) error {
+	if cycle := g.findCycle(src); cycle != nil {
+		return newErrCycle(cycle)
+	}
+	return newErrLoop(a, b)
+}
+
 func bestPaths(bestVerticies [][]int, src, dest int) [][]int {
 	paths := visitPath(bestVerticies, src, dest, dest)
 	best := [][]int{}
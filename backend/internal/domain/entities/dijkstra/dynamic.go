@@ -0,0 +1,199 @@
+package dijkstra
+
+// ShortestPathTree keeps the shortest paths from a single source up to date
+// while arcs change. Only the part of the tree affected by a change is
+// repaired instead of running Dijkstra again over the whole graph.
+type ShortestPathTree struct {
+	graph     Graph
+	incoming  []map[int]uint64
+	source    int
+	distances []uint64
+	previous  []int
+}
+
+// NewShortestPathTree builds the shortest path tree from src. The tree takes
+// ownership of g, changes to the graph must go through the tree from then on.
+func NewShortestPathTree(g Graph, src int) (*ShortestPathTree, error) {
+	if err := g.vertexValid(src); err != nil {
+		return nil, err
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	spt := &ShortestPathTree{
+		graph:    g,
+		incoming: make([]map[int]uint64, len(g.vertexArcs)),
+		source:   src,
+	}
+	for from, arcs := range g.vertexArcs {
+		for to, dist := range arcs {
+			if spt.incoming[to] == nil {
+				spt.incoming[to] = map[int]uint64{}
+			}
+			spt.incoming[to][from] = dist
+		}
+	}
+	tree := g.shortestFrom(src)
+	spt.distances = tree.distances
+	spt.previous = tree.previous
+	return spt, nil
+}
+
+// Source returns the vertex the tree is rooted at
+func (spt *ShortestPathTree) Source() int {
+	return spt.source
+}
+
+// Graph returns the graph the tree is built on, it must not be modified
+func (spt *ShortestPathTree) Graph() Graph {
+	return spt.graph
+}
+
+// Distance returns the shortest distance from the source to dest
+func (spt *ShortestPathTree) Distance(dest int) (uint64, error) {
+	if err := spt.graph.vertexValid(dest); err != nil {
+		return 0, err
+	}
+	if spt.distances[dest] == Infinity {
+		return 0, newErrNoPath(spt.source, dest)
+	}
+	return spt.distances[dest], nil
+}
+
+// Shortest returns the shortest path from the source to dest
+func (spt *ShortestPathTree) Shortest(dest int) (BestPath[int], error) {
+	distance, err := spt.Distance(dest)
+	if err != nil {
+		return BestPath[int]{}, err
+	}
+	var path []int
+	for c := dest; c != spt.source; c = spt.previous[c] {
+		path = append(path, c)
+	}
+	path = append(path, spt.source)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return BestPath[int]{distance, path}, nil
+}
+
+// AddArc adds an arc from src to dest, overwriting it if it already exists,
+// and repairs the tree
+func (spt *ShortestPathTree) AddArc(src, dest int, distance uint64) error {
+	if err := spt.graph.vertexValid(src); err != nil {
+		return err
+	}
+	if err := spt.graph.vertexValid(dest); err != nil {
+		return err
+	}
+	old, existed := spt.graph.vertexArcs[src][dest]
+	spt.graph.vertexArcs[src][dest] = distance
+	if spt.incoming[dest] == nil {
+		spt.incoming[dest] = map[int]uint64{}
+	}
+	spt.incoming[dest][src] = distance
+	if existed && distance > old {
+		if spt.previous[dest] == src {
+			spt.repairSubtree(dest)
+		}
+		return nil
+	}
+	spt.relaxArc(src, dest, distance)
+	return nil
+}
+
+// UpdateArc changes the distance of an existing arc and repairs the tree
+func (spt *ShortestPathTree) UpdateArc(src, dest int, distance uint64) error {
+	if _, err := spt.graph.GetArc(src, dest); err != nil {
+		return err
+	}
+	return spt.AddArc(src, dest, distance)
+}
+
+// RemoveArc removes the arc from src to dest and repairs the tree
+func (spt *ShortestPathTree) RemoveArc(src, dest int) error {
+	if _, err := spt.graph.GetArc(src, dest); err != nil {
+		return err
+	}
+	delete(spt.graph.vertexArcs[src], dest)
+	delete(spt.incoming[dest], src)
+	if spt.previous[dest] == src {
+		spt.repairSubtree(dest)
+	}
+	return nil
+}
+
+// relaxArc lowers the distance of dest if the arc from src gives a shorter
+// path, and propagates the improvement
+func (spt *ShortestPathTree) relaxArc(src, dest int, distance uint64) {
+	if spt.distances[src] == Infinity {
+		return
+	}
+	candidate := spt.distances[src] + distance
+	if candidate < spt.distances[src] || candidate >= spt.distances[dest] {
+		return
+	}
+	spt.distances[dest] = candidate
+	spt.previous[dest] = src
+	visiting := spt.graph.getList(listShortPQ)
+	visiting.PushOrdered(currentDistance{dest, candidate})
+	spt.propagate(visiting)
+}
+
+// repairSubtree recalculates the distances of every vertex whose shortest path
+// went through root, after the arc into root got longer or was removed
+func (spt *ShortestPathTree) repairSubtree(root int) {
+	subtree := []int{root}
+	inSubtree := map[int]bool{root: true}
+	for i := 0; i < len(subtree); i++ {
+		for to := range spt.graph.vertexArcs[subtree[i]] {
+			if spt.previous[to] == subtree[i] && !inSubtree[to] {
+				inSubtree[to] = true
+				subtree = append(subtree, to)
+			}
+		}
+	}
+	for _, v := range subtree {
+		spt.distances[v] = Infinity
+		spt.previous[v] = -1
+	}
+	visiting := spt.graph.getList(listShortPQ)
+	for _, v := range subtree {
+		for from, dist := range spt.incoming[v] {
+			if inSubtree[from] || spt.distances[from] == Infinity {
+				continue
+			}
+			candidate := spt.distances[from] + dist
+			if candidate >= spt.distances[from] && candidate < spt.distances[v] {
+				spt.distances[v] = candidate
+				spt.previous[v] = from
+			}
+		}
+		if spt.distances[v] != Infinity {
+			visiting.PushOrdered(currentDistance{v, spt.distances[v]})
+		}
+	}
+	spt.propagate(visiting)
+}
+
+// propagate runs Dijkstra from the vertices already in the list
+func (spt *ShortestPathTree) propagate(visiting dijkstraList) {
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		if current.distance > spt.distances[current.id] {
+			continue
+		}
+		for to, dist := range spt.graph.vertexArcs[current.id] {
+			candidate := current.distance + dist
+			if candidate < current.distance {
+				// overflow
+				continue
+			}
+			if candidate < spt.distances[to] {
+				spt.distances[to] = candidate
+				spt.previous[to] = current.id
+				visiting.PushOrdered(currentDistance{to, candidate})
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package dijkstra
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func assertTreeMatchesRecompute(t *testing.T, spt *ShortestPathTree, step int) {
+	t.Helper()
+	want := spt.graph.shortestFrom(spt.source)
+	for v := range spt.graph.vertexArcs {
+		if spt.distances[v] != want.distances[v] {
+			t.Fatalf("step %d: vertex %d distance %d, want %d", step, v, spt.distances[v], want.distances[v])
+		}
+		path, err := spt.Shortest(v)
+		if want.distances[v] == Infinity {
+			testErrors(t, ErrNoPath, err, step)
+			continue
+		}
+		testErrors(t, nil, err, step)
+		var distance uint64
+		for i := 0; i < len(path.Path)-1; i++ {
+			arc, err := spt.graph.GetArc(path.Path[i], path.Path[i+1])
+			if err != nil {
+				t.Fatalf("step %d: path %v uses missing arc", step, path.Path)
+			}
+			distance += arc
+		}
+		if distance != path.Distance {
+			t.Fatalf("step %d: path %v adds up to %d, want %d", step, path.Path, distance, path.Distance)
+		}
+	}
+}
+
+func TestShortestPathTree(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		spt, err := NewShortestPathTree(constrainedTestGraph(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d, _ := spt.Distance(4); d != 4 {
+			t.Fatal("wrong initial distance", d)
+		}
+		spt.UpdateArc(2, 3, 10)
+		if d, _ := spt.Distance(4); d != 7 {
+			t.Fatal("wrong distance after increase", d)
+		}
+		spt.RemoveArc(1, 3)
+		if d, _ := spt.Distance(4); d != 12 {
+			t.Fatal("wrong distance after remove", d)
+		}
+		spt.AddArc(1, 4, 1)
+		if d, _ := spt.Distance(4); d != 2 {
+			t.Fatal("wrong distance after add", d)
+		}
+		path, _ := spt.Shortest(4)
+		testResults(t, BestPath[int]{2, []int{0, 1, 4}}, path, true, 0)
+		spt.RemoveArc(0, 1)
+		spt.RemoveArc(0, 2)
+		spt.RemoveArc(0, 4)
+		_, err = spt.Distance(4)
+		testErrors(t, ErrNoPath, err, 0)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		_, err := NewShortestPathTree(constrainedTestGraph(), 9)
+		testErrors(t, ErrVertexNotFound, err, 0)
+		spt, _ := NewShortestPathTree(constrainedTestGraph(), 0)
+		testErrors(t, ErrArcNotFound, spt.UpdateArc(0, 3, 1), 0)
+		testErrors(t, ErrArcNotFound, spt.RemoveArc(0, 3), 0)
+		testErrors(t, ErrVertexNotFound, spt.AddArc(0, 9, 1), 0)
+		testErrors(t, ErrVertexNegative, spt.AddArc(-1, 0, 1), 0)
+	})
+	t.Run("Random", func(t *testing.T) {
+		nodes := 60
+		g := Generate(nodes)
+		spt, err := NewShortestPathTree(g, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertTreeMatchesRecompute(t, spt, -1)
+		seeded := rand.New(rand.NewSource(int64(nodes)))
+		for step := range 500 {
+			src, dest := seeded.Intn(nodes), seeded.Intn(nodes)
+			if src == dest {
+				continue
+			}
+			_, exists := spt.graph.vertexArcs[src][dest]
+			switch {
+			case exists && step%3 == 0:
+				err = spt.RemoveArc(src, dest)
+			case exists:
+				err = spt.UpdateArc(src, dest, uint64(seeded.Int63n(int64(nodes*nodes))))
+			default:
+				err = spt.AddArc(src, dest, uint64(seeded.Int63n(int64(nodes*nodes))))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertTreeMatchesRecompute(t, spt, step)
+		}
+	})
+}
+
+func BenchmarkShortestPathTree(b *testing.B) {
+	for _, nodes := range []int{1000, 10000} {
+		if nodes > 1000 && testing.Short() {
+			continue
+		}
+		g := Generate(nodes)
+		spt, _ := NewShortestPathTree(g, 2)
+		seeded := rand.New(rand.NewSource(int64(nodes)))
+		changes := make([]Arc[int], 1000)
+		for i := range changes {
+			src := 2 + seeded.Intn(nodes-2)
+			for dest := range g.vertexArcs[src] {
+				changes[i] = Arc[int]{src, dest}
+				break
+			}
+		}
+		weight := func(i int) uint64 {
+			return uint64(seeded.Int63n(int64(nodes) * int64(nodes)))
+		}
+		b.Run(strconv.Itoa(nodes)+"Nodes/Incremental", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				change := changes[i%len(changes)]
+				spt.UpdateArc(change.Src, change.Dest, weight(i))
+			}
+		})
+		b.Run(strconv.Itoa(nodes)+"Nodes/Recompute", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				change := changes[i%len(changes)]
+				g.vertexArcs[change.Src][change.Dest] = weight(i)
+				g.shortestFrom(2)
+			}
+		})
+	}
+}
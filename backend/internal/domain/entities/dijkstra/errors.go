@@ -15,6 +15,9 @@ var ErrArcNotFound = errors.New("arc not found")
 var ErrGraphNotValid = errors.New("graph is not valid")
 var ErrMapNotFound = errors.New("mapping error, can not find mapped vertex")
 var ErrArcHanging = errors.New("arc will be left hanging")
+var ErrConstraintsNotValid = errors.New("constraints are not valid")
+var ErrNotStronglyConnected = errors.New("graph is not strongly connected")
+var ErrFlowNotValid = errors.New("flow is not valid")
 
 // not found/item validity
 func newErrMapNotFound(a int) error {
@@ -40,12 +43,42 @@ func newArcHanging[T comparable](a, b T) error {
 func newErrLoop(a, b int) error {
 	return fmt.Errorf("%w, from node '%d' to node '%d'", ErrLoopDetected, a, b)
 }
+
+// CycleError is an ErrLoopDetected that knows the cycle, the last vertex of
+// Cycle has an arc to the first one
+type CycleError[T comparable] struct {
+	Cycle []T
+}
+
+func (e CycleError[T]) Error() string {
+	return fmt.Sprintf("%s, cycle %v", ErrLoopDetected, e.Cycle)
+}
+
+func (e CycleError[T]) Unwrap() error {
+	return ErrLoopDetected
+}
+
+func newErrCycle(cycle []int) error {
+	return CycleError[int]{cycle}
+}
 func newErrGraphNotValid(a, b int) error {
 	return fmt.Errorf("%w, arc %d->%d, %d not found", ErrGraphNotValid, a, b, b)
 }
 func newErrNoPath(a, b int) error {
 	return fmt.Errorf("%d->%d %w", a, b, ErrNoPath)
 }
+func newErrNotStronglyConnected(cause error) error {
+	return fmt.Errorf("%w, %w", ErrNotStronglyConnected, cause)
+}
+func newErrConstraintsNotValid(reason string) error {
+	return fmt.Errorf("%w, %s", ErrConstraintsNotValid, reason)
+}
+func newErrFlowNotValid(reason string) error {
+	return fmt.Errorf("%w, %s", ErrFlowNotValid, reason)
+}
+func newErrSourceIsSink(a int) error {
+	return newErrFlowNotValid(fmt.Sprintf("%d is both a source and a sink", a))
+}
 
 // mappped
 func newErrMappedVertexNotFound[T comparable](a T) error {
@@ -0,0 +1,216 @@
+package dijkstra
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Flow is a maximum flow through the graph, arc distances are used as
+// capacities
+type Flow[T comparable] struct {
+	Value uint64
+	// Flows has the flow sent over every arc that carries some
+	Flows map[Arc[T]]uint64
+	// MinCut has the saturated arcs that separate the sources from the sinks,
+	// their capacities add up to Value
+	MinCut []Arc[T]
+}
+
+// MaxFlow returns the maximum flow from src to sink (Dinic), arc distances
+// are used as capacities. Min cut arcs are sorted.
+func (g Graph) MaxFlow(src, sink int) (Flow[int], error) {
+	return g.MaxFlowMulti([]int{src}, []int{sink})
+}
+
+// MaxFlowMulti returns the maximum flow from a set of sources to a set of
+// sinks, as if a super source fed every source and every sink fed a super sink
+// through arcs of unlimited capacity
+func (g Graph) MaxFlowMulti(sources, sinks []int) (Flow[int], error) {
+	if len(sources) == 0 || len(sinks) == 0 {
+		return Flow[int]{}, newErrFlowNotValid("sources and sinks can not be empty")
+	}
+	isSource := map[int]bool{}
+	for _, v := range sources {
+		if err := g.vertexValid(v); err != nil {
+			return Flow[int]{}, err
+		}
+		isSource[v] = true
+	}
+	for _, v := range sinks {
+		if err := g.vertexValid(v); err != nil {
+			return Flow[int]{}, err
+		}
+		if isSource[v] {
+			return Flow[int]{}, newErrSourceIsSink(v)
+		}
+	}
+
+	n := len(g.vertexArcs)
+	network := newFlowNetwork(n + 2)
+	superSource, superSink := n, n+1
+	for from, arcs := range g.sortedAdjacency() {
+		for _, to := range arcs {
+			if to != from {
+				network.addEdge(from, to, g.vertexArcs[from][to])
+			}
+		}
+	}
+	original := len(network.to)
+	for _, v := range sources {
+		network.addEdge(superSource, v, Infinity)
+	}
+	for _, v := range sinks {
+		network.addEdge(v, superSink, Infinity)
+	}
+
+	flow := Flow[int]{
+		Value: network.maxFlow(superSource, superSink),
+		Flows: map[Arc[int]]uint64{},
+	}
+	reached := network.reachable(superSource)
+	for e := 0; e < original; e += 2 {
+		from, to := network.to[e+1], network.to[e]
+		if sent := network.capacity[e+1]; sent > 0 {
+			flow.Flows[Arc[int]{from, to}] = sent
+		}
+		if reached[from] && !reached[to] && g.vertexArcs[from][to] > 0 {
+			flow.MinCut = append(flow.MinCut, Arc[int]{from, to})
+		}
+	}
+	slices.SortFunc(flow.MinCut, func(a, b Arc[int]) int {
+		return cmp.Or(cmp.Compare(a.Src, b.Src), cmp.Compare(a.Dest, b.Dest))
+	})
+	return flow, nil
+}
+
+// flowNetwork is a residual graph, edge e and e^1 are the two directions of
+// the same arc and capacity is what is left to send over each of them
+type flowNetwork struct {
+	edges    [][]int
+	to       []int
+	capacity []uint64
+	level    []int
+	next     []int
+}
+
+func newFlowNetwork(vertices int) flowNetwork {
+	return flowNetwork{
+		edges: make([][]int, vertices),
+		level: make([]int, vertices),
+		next:  make([]int, vertices),
+	}
+}
+
+func (f *flowNetwork) addEdge(from, to int, capacity uint64) {
+	f.edges[from] = append(f.edges[from], len(f.to))
+	f.to = append(f.to, to)
+	f.capacity = append(f.capacity, capacity)
+	f.edges[to] = append(f.edges[to], len(f.to))
+	f.to = append(f.to, from)
+	f.capacity = append(f.capacity, 0)
+}
+
+func (f *flowNetwork) maxFlow(src, sink int) uint64 {
+	var total uint64
+	for f.buildLevels(src, sink) {
+		for i := range f.next {
+			f.next[i] = 0
+		}
+		for {
+			pushed := f.push(src, sink, Infinity)
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+// buildLevels sets the BFS distance of every vertex from src over edges with
+// capacity left, it returns false once sink can not be reached
+func (f *flowNetwork) buildLevels(src, sink int) bool {
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[src] = 0
+	queue := []int{src}
+	for i := 0; i < len(queue); i++ {
+		for _, e := range f.edges[queue[i]] {
+			if f.capacity[e] > 0 && f.level[f.to[e]] == -1 {
+				f.level[f.to[e]] = f.level[queue[i]] + 1
+				queue = append(queue, f.to[e])
+			}
+		}
+	}
+	return f.level[sink] != -1
+}
+
+// push sends up to limit along one path of increasing levels
+func (f *flowNetwork) push(v, sink int, limit uint64) uint64 {
+	if v == sink {
+		return limit
+	}
+	for ; f.next[v] < len(f.edges[v]); f.next[v]++ {
+		e := f.edges[v][f.next[v]]
+		to := f.to[e]
+		if f.capacity[e] == 0 || f.level[to] != f.level[v]+1 {
+			continue
+		}
+		if pushed := f.push(to, sink, min(limit, f.capacity[e])); pushed > 0 {
+			f.capacity[e] -= pushed
+			f.capacity[e^1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable returns the vertices reached from src over edges with capacity
+// left, the source side of the min cut once the flow is maximum
+func (f *flowNetwork) reachable(src int) []bool {
+	reached := make([]bool, len(f.edges))
+	reached[src] = true
+	queue := []int{src}
+	for i := 0; i < len(queue); i++ {
+		for _, e := range f.edges[queue[i]] {
+			if f.capacity[e] > 0 && !reached[f.to[e]] {
+				reached[f.to[e]] = true
+				queue = append(queue, f.to[e])
+			}
+		}
+	}
+	return reached
+}
+
+// MaxFlow returns the maximum flow from src to sink, arc distances are used as
+// capacities
+func (mg MappedGraph[T]) MaxFlow(src, sink T) (Flow[T], error) {
+	return mg.MaxFlowMulti([]T{src}, []T{sink})
+}
+
+// MaxFlowMulti returns the maximum flow from a set of sources to a set of
+// sinks
+func (mg MappedGraph[T]) MaxFlowMulti(sources, sinks []T) (Flow[T], error) {
+	sourceIDs, err := mg.getMaps(sources)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	sinkIDs, err := mg.getMaps(sinks)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	flow, err := mg.graph.MaxFlowMulti(sourceIDs, sinkIDs)
+	if err != nil {
+		return Flow[T]{}, err
+	}
+	vertices := mg.indexedVertices()
+	mapped := Flow[T]{Value: flow.Value, Flows: make(map[Arc[T]]uint64, len(flow.Flows))}
+	for arc, sent := range flow.Flows {
+		mapped.Flows[Arc[T]{vertices[arc.Src], vertices[arc.Dest]}] = sent
+	}
+	for _, arc := range flow.MinCut {
+		mapped.MinCut = append(mapped.MinCut, Arc[T]{vertices[arc.Src], vertices[arc.Dest]})
+	}
+	return mapped, nil
+}
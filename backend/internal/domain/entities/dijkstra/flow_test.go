@@ -0,0 +1,118 @@
+package dijkstra
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// flowTestGraph is the classic CLRS flow network, 0 is the source and 5 the
+// sink, its maximum flow is 23
+func flowTestGraph() Graph {
+	return Graph{
+		[]map[int]uint64{
+			{1: 16, 2: 13},
+			{3: 12},
+			{1: 4, 4: 14},
+			{2: 9, 5: 20},
+			{3: 7, 5: 4},
+			{},
+		},
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	g := flowTestGraph()
+	flow, err := g.MaxFlow(0, 5)
+	testErrors(t, nil, err, 0)
+	if flow.Value != 23 {
+		t.Error("wrong max flow", flow.Value)
+	}
+	if want := []Arc[int]{{1, 3}, {4, 3}, {4, 5}}; !reflect.DeepEqual(flow.MinCut, want) {
+		t.Error("wrong min cut", flow.MinCut)
+	}
+	testFlowValid(t, g, flow, []int{0}, []int{5})
+
+	// nothing flows backwards
+	flow, err = g.MaxFlow(5, 0)
+	testErrors(t, nil, err, 1)
+	if flow.Value != 0 || len(flow.Flows) != 0 || len(flow.MinCut) != 0 {
+		t.Error("no flow expected", flow)
+	}
+
+	_, err = g.MaxFlow(0, 0)
+	testErrors(t, ErrFlowNotValid, err, 2)
+	_, err = g.MaxFlow(0, 9)
+	testErrors(t, ErrVertexNotFound, err, 3)
+	_, err = g.MaxFlowMulti(nil, []int{5})
+	testErrors(t, ErrFlowNotValid, err, 4)
+}
+
+func TestMaxFlowMulti(t *testing.T) {
+	g := flowTestGraph()
+	flow, err := g.MaxFlowMulti([]int{1, 2}, []int{3, 4})
+	testErrors(t, nil, err, 0)
+	// 1 sends 12 to 3, 2 sends 14 to 4 and 4 over 1 does not help
+	if flow.Value != 26 {
+		t.Error("wrong max flow", flow.Value)
+	}
+	testFlowValid(t, g, flow, []int{1, 2}, []int{3, 4})
+
+	for _, n := range []int{10, 60} {
+		g := Generate(n)
+		single, _ := g.MaxFlow(2, n-1)
+		testFlowValid(t, g, single, []int{2}, []int{n - 1})
+		multi, err := g.MaxFlowMulti([]int{2, 3}, []int{n - 1, n - 2})
+		testErrors(t, nil, err, n)
+		testFlowValid(t, g, multi, []int{2, 3}, []int{n - 1, n - 2})
+		if multi.Value < single.Value {
+			t.Error("adding sources and sinks can not reduce the flow", n)
+		}
+	}
+}
+
+func TestMappedMaxFlow(t *testing.T) {
+	mg, _ := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	flow, err := mg.MaxFlow("A", "F")
+	testErrors(t, nil, err, 0)
+	if flow.Value != 6 || !reflect.DeepEqual(flow.MinCut, []Arc[string]{{"A", "B"}, {"A", "C"}}) {
+		t.Error("wrong flow", flow)
+	}
+	_, err = mg.MaxFlowMulti([]string{"A"}, []string{"Z"})
+	testErrors(t, ErrVertexNotFound, err, 1)
+}
+
+// testFlowValid checks capacities, conservation and that the min cut matches
+// the flow value
+func testFlowValid(t *testing.T, g Graph, flow Flow[int], sources, sinks []int) {
+	t.Helper()
+	balance := make([]int64, len(g.vertexArcs))
+	for arc, sent := range flow.Flows {
+		if sent > g.vertexArcs[arc.Src][arc.Dest] {
+			t.Fatal("flow over capacity", arc, sent)
+		}
+		balance[arc.Src] -= int64(sent)
+		balance[arc.Dest] += int64(sent)
+	}
+	var out, in int64
+	for v, b := range balance {
+		switch {
+		case slices.Contains(sources, v):
+			out -= b
+		case slices.Contains(sinks, v):
+			in += b
+		case b != 0:
+			t.Fatal("flow not conserved at", v, b)
+		}
+	}
+	if uint64(out) != flow.Value || uint64(in) != flow.Value {
+		t.Fatal("flow value does not match", out, in, flow.Value)
+	}
+	var cut uint64
+	for _, arc := range flow.MinCut {
+		cut += g.vertexArcs[arc.Src][arc.Dest]
+	}
+	if cut != flow.Value {
+		t.Fatal("min cut does not match flow value", cut, flow.Value)
+	}
+}
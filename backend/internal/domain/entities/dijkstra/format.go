@@ -0,0 +1,129 @@
+package dijkstra
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// namedVertex is a vertex ready to be written, with its arcs sorted by the
+// index of their destination
+type namedVertex struct {
+	name string
+	arcs []namedArc
+}
+
+type namedArc struct {
+	to     string
+	weight uint64
+}
+
+// namedVertices returns the existing vertices of the graph ordered by index
+func (g Graph) namedVertices() []namedVertex {
+	var vertices []namedVertex
+	for id, arcs := range g.vertexArcs {
+		if arcs == nil {
+			continue
+		}
+		vertex := namedVertex{name: strconv.Itoa(id)}
+		for _, to := range sortedKeys(arcs) {
+			vertex.arcs = append(vertex.arcs, namedArc{strconv.Itoa(to), arcs[to]})
+		}
+		vertices = append(vertices, vertex)
+	}
+	return vertices
+}
+
+// namedVertices returns the mapped vertices ordered by index, named with
+// fmt.Sprint
+func (mg MappedGraph[T]) namedVertices() ([]namedVertex, error) {
+	names := mg.indexedVertices()
+	var vertices []namedVertex
+	for _, item := range mg.orderedVertices() {
+		id := mg.mapping[item]
+		if err := mg.graph.vertexValid(id); err != nil {
+			continue
+		}
+		vertex := namedVertex{name: fmt.Sprint(item)}
+		for _, to := range sortedKeys(mg.graph.vertexArcs[id]) {
+			if to >= len(names) {
+				return nil, newErrMapNotFound(to)
+			}
+			vertex.arcs = append(vertex.arcs, namedArc{fmt.Sprint(names[to]), mg.graph.vertexArcs[id][to]})
+		}
+		vertices = append(vertices, vertex)
+	}
+	return vertices, nil
+}
+
+func sortedKeys(arcs map[int]uint64) []int {
+	keys := make([]int, 0, len(arcs))
+	for k := range arcs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// graphBuilder receives the vertices and arcs found by the readers
+type graphBuilder interface {
+	addVertex(name string) error
+	addArc(from, to string, weight uint64) error
+}
+
+// intBuilder builds a Graph, vertex names must be non negative integers
+type intBuilder struct {
+	graph *Graph
+}
+
+func (b intBuilder) addVertex(name string) error {
+	index, err := strconv.Atoi(name)
+	if err != nil {
+		return fmt.Errorf("%w, vertex %q is not an integer", ErrWrongFormat, name)
+	}
+	if err = b.graph.vertexOK(index); err != nil {
+		return err
+	}
+	if b.graph.vertexExists(index) != nil {
+		b.graph.addVertex(index, map[int]uint64{})
+	}
+	return nil
+}
+
+func (b intBuilder) addArc(from, to string, weight uint64) error {
+	if err := b.addVertex(from); err != nil {
+		return err
+	}
+	if err := b.addVertex(to); err != nil {
+		return err
+	}
+	src, _ := strconv.Atoi(from)
+	dest, _ := strconv.Atoi(to)
+	b.graph.vertexArcs[src][dest] = weight
+	return nil
+}
+
+// mappedBuilder builds a MappedGraph, vertices are indexed in the order they
+// are found
+type mappedBuilder struct {
+	graph *MappedGraph[string]
+}
+
+func (b mappedBuilder) addVertex(name string) error {
+	if _, ok := b.graph.mapping[name]; ok {
+		return nil
+	}
+	_, err := b.graph.addMap(name)
+	return err
+}
+
+func (b mappedBuilder) addArc(from, to string, weight uint64) error {
+	if err := b.addVertex(from); err != nil {
+		return err
+	}
+	if err := b.addVertex(to); err != nil {
+		return err
+	}
+	b.graph.graph.vertexArcs[b.graph.mapping[from]][b.graph.mapping[to]] = weight
+	return nil
+}
@@ -0,0 +1,345 @@
+package dijkstra
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WriteDOT writes the graph in Graphviz DOT format, arc distances are written
+// as the weight attribute. Vertices and arcs are ordered by index.
+func (g Graph) WriteDOT(w io.Writer) error {
+	return writeDOT(w, g.namedVertices())
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, vertices are named with
+// fmt.Sprint
+func (mg MappedGraph[T]) WriteDOT(w io.Writer) error {
+	vertices, err := mg.namedVertices()
+	if err != nil {
+		return err
+	}
+	return writeDOT(w, vertices)
+}
+
+// ReadDOT reads a graph in Graphviz DOT format, vertex IDs must be integers.
+// Arcs without a weight attribute get a distance of 1 and undirected edges add
+// an arc in each direction.
+func ReadDOT(r io.Reader) (Graph, error) {
+	g := NewGraph()
+	if err := readDOT(r, intBuilder{&g}); err != nil {
+		return g, err
+	}
+	return g, g.validate()
+}
+
+// ReadDOTMapped reads a graph in Graphviz DOT format
+func ReadDOTMapped(r io.Reader) (MappedGraph[string], error) {
+	mg := NewMappedGraph[string]()
+	if err := readDOT(r, mappedBuilder{&mg}); err != nil {
+		return mg, err
+	}
+	return mg, mg.validate()
+}
+
+func writeDOT(w io.Writer, vertices []namedVertex) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph {\n")
+	for _, v := range vertices {
+		fmt.Fprintf(bw, "\t%s;\n", quoteDOT(v.name))
+	}
+	for _, v := range vertices {
+		for _, arc := range v.arcs {
+			fmt.Fprintf(bw, "\t%s -> %s [weight=%d];\n", quoteDOT(v.name), quoteDOT(arc.to), arc.weight)
+		}
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+func quoteDOT(id string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(id) + `"`
+}
+
+// dotScanner splits a DOT document into tokens, comments are skipped
+type dotScanner struct {
+	r *bufio.Reader
+}
+
+func (s dotScanner) next() (string, error) {
+	for {
+		c, _, err := s.r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case unicode.IsSpace(c) || c == ';' || c == ',':
+			continue
+		case c == '#':
+			s.skipLine()
+		case c == '/':
+			n, _, err := s.r.ReadRune()
+			if err != nil {
+				return "", fmt.Errorf("%w, unexpected '/'", ErrWrongFormat)
+			}
+			switch n {
+			case '/':
+				s.skipLine()
+			case '*':
+				if err := s.skipBlockComment(); err != nil {
+					return "", err
+				}
+			default:
+				return "", fmt.Errorf("%w, unexpected '/'", ErrWrongFormat)
+			}
+		case c == '{' || c == '}' || c == '[' || c == ']' || c == '=':
+			return string(c), nil
+		case c == '-':
+			n, _, err := s.r.ReadRune()
+			if err == nil && (n == '>' || n == '-') {
+				return string([]rune{c, n}), nil
+			}
+			if err == nil {
+				s.r.UnreadRune()
+			}
+			return s.identifier(c)
+		case c == '"':
+			return s.quoted()
+		default:
+			return s.identifier(c)
+		}
+	}
+}
+
+func (s dotScanner) skipLine() {
+	s.r.ReadString('\n')
+}
+
+func (s dotScanner) skipBlockComment() error {
+	var previous rune
+	for {
+		c, _, err := s.r.ReadRune()
+		if err != nil {
+			return fmt.Errorf("%w, unterminated comment", ErrWrongFormat)
+		}
+		if previous == '*' && c == '/' {
+			return nil
+		}
+		previous = c
+	}
+}
+
+// quoted reads a quoted ID, the token keeps the opening quote so it can not be
+// mistaken for a keyword
+func (s dotScanner) quoted() (string, error) {
+	var sb strings.Builder
+	sb.WriteRune('"')
+	for {
+		c, _, err := s.r.ReadRune()
+		if err != nil {
+			return "", fmt.Errorf("%w, unterminated string", ErrWrongFormat)
+		}
+		switch c {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			n, _, err := s.r.ReadRune()
+			if err != nil {
+				return "", fmt.Errorf("%w, unterminated string", ErrWrongFormat)
+			}
+			if n != '"' && n != '\\' {
+				sb.WriteRune(c)
+			}
+			sb.WriteRune(n)
+		default:
+			sb.WriteRune(c)
+		}
+	}
+}
+
+func (s dotScanner) identifier(first rune) (string, error) {
+	var sb strings.Builder
+	sb.WriteRune(first)
+	for {
+		c, _, err := s.r.ReadRune()
+		if errors.Is(err, io.EOF) {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '.') {
+			s.r.UnreadRune()
+			return sb.String(), nil
+		}
+		sb.WriteRune(c)
+	}
+}
+
+// dotID returns the ID of a token, if it is one
+func dotID(token string) (string, bool) {
+	if strings.HasPrefix(token, `"`) {
+		return token[1:], true
+	}
+	switch token {
+	case "{", "}", "[", "]", "=", "->", "--", "":
+		return "", false
+	}
+	return token, true
+}
+
+func readDOT(r io.Reader, b graphBuilder) error {
+	s := dotScanner{bufio.NewReader(r)}
+	token, err := s.next()
+	if err != nil {
+		return fmt.Errorf("%w, empty document", ErrWrongFormat)
+	}
+	if strings.EqualFold(token, "strict") {
+		if token, err = s.next(); err != nil {
+			return fmt.Errorf("%w, missing graph", ErrWrongFormat)
+		}
+	}
+	var edgeOp string
+	switch strings.ToLower(token) {
+	case "digraph":
+		edgeOp = "->"
+	case "graph":
+		edgeOp = "--"
+	default:
+		return fmt.Errorf("%w, expected graph or digraph, got %q", ErrWrongFormat, token)
+	}
+	if token, err = s.next(); err != nil {
+		return fmt.Errorf("%w, missing body", ErrWrongFormat)
+	}
+	if token != "{" {
+		if token, err = s.next(); err != nil || token != "{" {
+			return fmt.Errorf("%w, missing body", ErrWrongFormat)
+		}
+	}
+
+	graphKind := "directed"
+	if edgeOp == "--" {
+		graphKind = "undirected"
+	}
+	token, err = s.next()
+	for {
+		if errors.Is(err, ErrWrongFormat) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("%w, unterminated body", ErrWrongFormat)
+		}
+		if token == "}" {
+			return nil
+		}
+		id, ok := dotID(token)
+		if !ok {
+			return fmt.Errorf("%w, unexpected %q", ErrWrongFormat, token)
+		}
+		if !strings.HasPrefix(token, `"`) {
+			switch strings.ToLower(token) {
+			case "graph", "node", "edge":
+				// default attributes do not change the graph
+				if token, err = s.next(); err == nil && token == "[" {
+					_, err = readDOTAttributes(s)
+					token, err = nextIfNil(s, err)
+				}
+				continue
+			case "subgraph":
+				return fmt.Errorf("%w, subgraphs are not supported", ErrWrongFormat)
+			}
+		}
+		chain := []string{id}
+		token, err = s.next()
+		if token == "=" {
+			// graph attribute, id = id
+			if _, err = s.next(); err != nil {
+				return fmt.Errorf("%w, missing attribute value", ErrWrongFormat)
+			}
+			token, err = s.next()
+			continue
+		}
+		for err == nil && (token == "->" || token == "--") {
+			if token != edgeOp {
+				return fmt.Errorf("%w, %s used in a %s graph", ErrWrongFormat, token, graphKind)
+			}
+			if token, err = s.next(); err != nil {
+				return fmt.Errorf("%w, missing edge target", ErrWrongFormat)
+			}
+			to, ok := dotID(token)
+			if !ok {
+				return fmt.Errorf("%w, unexpected %q", ErrWrongFormat, token)
+			}
+			chain = append(chain, to)
+			token, err = s.next()
+		}
+		attributes := map[string]string{}
+		if err == nil && token == "[" {
+			attributes, err = readDOTAttributes(s)
+			token, err = nextIfNil(s, err)
+		}
+		if len(chain) == 1 {
+			if e := b.addVertex(chain[0]); e != nil {
+				return e
+			}
+			continue
+		}
+		weight := uint64(1)
+		if value, ok := attributes["weight"]; ok {
+			var e error
+			if weight, e = strconv.ParseUint(value, 10, 64); e != nil {
+				return fmt.Errorf("%w, weight %q is not a non negative integer", ErrWrongFormat, value)
+			}
+		}
+		for i := 0; i < len(chain)-1; i++ {
+			if e := b.addArc(chain[i], chain[i+1], weight); e != nil {
+				return e
+			}
+			if edgeOp == "--" {
+				if e := b.addArc(chain[i+1], chain[i], weight); e != nil {
+					return e
+				}
+			}
+		}
+	}
+}
+
+func nextIfNil(s dotScanner, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return s.next()
+}
+
+// readDOTAttributes reads key=value pairs until the closing bracket
+func readDOTAttributes(s dotScanner) (map[string]string, error) {
+	attributes := map[string]string{}
+	for {
+		token, err := s.next()
+		if err != nil {
+			return nil, fmt.Errorf("%w, unterminated attribute list", ErrWrongFormat)
+		}
+		if token == "]" {
+			return attributes, nil
+		}
+		key, ok := dotID(token)
+		if !ok {
+			return nil, fmt.Errorf("%w, unexpected %q", ErrWrongFormat, token)
+		}
+		if token, err = s.next(); err != nil || token != "=" {
+			return nil, fmt.Errorf("%w, attribute %q has no value", ErrWrongFormat, key)
+		}
+		if token, err = s.next(); err != nil {
+			return nil, fmt.Errorf("%w, attribute %q has no value", ErrWrongFormat, key)
+		}
+		value, ok := dotID(token)
+		if !ok {
+			return nil, fmt.Errorf("%w, unexpected %q", ErrWrongFormat, token)
+		}
+		attributes[key] = value
+	}
+}
@@ -0,0 +1,178 @@
+package dijkstra
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// WriteGraphML writes the graph in GraphML format, arc distances are written
+// as the weight data of each edge. Vertices and arcs are ordered by index.
+func (g Graph) WriteGraphML(w io.Writer) error {
+	return writeGraphML(w, g.namedVertices())
+}
+
+// WriteGraphML writes the graph in GraphML format, vertices are named with
+// fmt.Sprint
+func (mg MappedGraph[T]) WriteGraphML(w io.Writer) error {
+	vertices, err := mg.namedVertices()
+	if err != nil {
+		return err
+	}
+	return writeGraphML(w, vertices)
+}
+
+// ReadGraphML reads a graph in GraphML format, node IDs must be integers.
+// Edges without weight data get a distance of 1 and undirected edges add an
+// arc in each direction.
+func ReadGraphML(r io.Reader) (Graph, error) {
+	g := NewGraph()
+	if err := readGraphML(r, intBuilder{&g}); err != nil {
+		return g, err
+	}
+	return g, g.validate()
+}
+
+// ReadGraphMLMapped reads a graph in GraphML format
+func ReadGraphMLMapped(r io.Reader) (MappedGraph[string], error) {
+	mg := NewMappedGraph[string]()
+	if err := readGraphML(r, mappedBuilder{&mg}); err != nil {
+		return mg, err
+	}
+	return mg, mg.validate()
+}
+
+func writeGraphML(w io.Writer, vertices []namedVertex) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xml.Header)
+	fmt.Fprintf(bw, "<graphml xmlns=%q>\n", graphMLNamespace)
+	bw.WriteString("  <key id=\"weight\" for=\"edge\" attr.name=\"weight\" attr.type=\"long\"/>\n")
+	bw.WriteString("  <graph id=\"G\" edgedefault=\"directed\">\n")
+	for _, v := range vertices {
+		fmt.Fprintf(bw, "    <node id=\"%s\"/>\n", escapeXML(v.name))
+	}
+	for _, v := range vertices {
+		for _, arc := range v.arcs {
+			fmt.Fprintf(bw, "    <edge source=\"%s\" target=\"%s\"><data key=\"weight\">%d</data></edge>\n",
+				escapeXML(v.name), escapeXML(arc.to), arc.weight)
+		}
+	}
+	bw.WriteString("  </graph>\n")
+	bw.WriteString("</graphml>\n")
+	return bw.Flush()
+}
+
+func escapeXML(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+func xmlAttr(e xml.StartElement, name string) (string, bool) {
+	for _, attr := range e.Attr {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// graphMLEdge is an edge waiting for its weight data
+type graphMLEdge struct {
+	source, target string
+	directed       bool
+	weight         uint64
+}
+
+func readGraphML(r io.Reader, b graphBuilder) error {
+	decoder := xml.NewDecoder(r)
+	// keys whose attribute name is weight, usually just "weight"
+	weightKeys := map[string]bool{}
+	directed := true
+	foundGraph := false
+	var edge *graphMLEdge
+	var dataKey string
+	var dataText strings.Builder
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w, %w", ErrWrongFormat, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "key":
+				id, _ := xmlAttr(t, "id")
+				name, _ := xmlAttr(t, "attr.name")
+				if target, ok := xmlAttr(t, "for"); name == "weight" && (!ok || target == "edge" || target == "all") {
+					weightKeys[id] = true
+				}
+			case "graph":
+				if foundGraph {
+					return fmt.Errorf("%w, nested graphs are not supported", ErrWrongFormat)
+				}
+				foundGraph = true
+				if value, ok := xmlAttr(t, "edgedefault"); ok {
+					directed = value != "undirected"
+				}
+			case "node":
+				id, ok := xmlAttr(t, "id")
+				if !ok {
+					return fmt.Errorf("%w, node without id", ErrWrongFormat)
+				}
+				if err := b.addVertex(id); err != nil {
+					return err
+				}
+			case "edge":
+				source, okSource := xmlAttr(t, "source")
+				target, okTarget := xmlAttr(t, "target")
+				if !okSource || !okTarget {
+					return fmt.Errorf("%w, edge without source or target", ErrWrongFormat)
+				}
+				edge = &graphMLEdge{source: source, target: target, directed: directed, weight: 1}
+				if value, ok := xmlAttr(t, "directed"); ok {
+					edge.directed = value != "false"
+				}
+			case "data":
+				dataKey, _ = xmlAttr(t, "key")
+				dataText.Reset()
+			}
+		case xml.CharData:
+			dataText.Write(t)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "data":
+				if edge != nil && weightKeys[dataKey] {
+					value := strings.TrimSpace(dataText.String())
+					if edge.weight, err = strconv.ParseUint(value, 10, 64); err != nil {
+						return fmt.Errorf("%w, weight %q is not a non negative integer", ErrWrongFormat, value)
+					}
+				}
+				dataKey = ""
+			case "edge":
+				if err := b.addArc(edge.source, edge.target, edge.weight); err != nil {
+					return err
+				}
+				if !edge.directed {
+					if err := b.addArc(edge.target, edge.source, edge.weight); err != nil {
+						return err
+					}
+				}
+				edge = nil
+			}
+		}
+	}
+	if !foundGraph {
+		return fmt.Errorf("%w, missing graph element", ErrWrongFormat)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package dijkstra
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonVertex is a vertex of the JSON adjacency format:
+//
+//	{"vertices": [{"id": "A", "arcs": [{"to": "B", "weight": 4}]}]}
+type jsonVertex struct {
+	ID   string    `json:"id"`
+	Arcs []jsonArc `json:"arcs"`
+}
+
+type jsonArc struct {
+	To     string `json:"to"`
+	Weight uint64 `json:"weight"`
+}
+
+// WriteJSON writes the graph in the JSON adjacency format, one vertex at a
+// time. Vertices and arcs are ordered by index.
+func (g Graph) WriteJSON(w io.Writer) error {
+	return writeJSON(w, g.namedVertices())
+}
+
+// WriteJSON writes the graph in the JSON adjacency format, vertices are named
+// with fmt.Sprint
+func (mg MappedGraph[T]) WriteJSON(w io.Writer) error {
+	vertices, err := mg.namedVertices()
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, vertices)
+}
+
+// ReadJSON reads a graph in the JSON adjacency format, vertex IDs must be
+// integers
+func ReadJSON(r io.Reader) (Graph, error) {
+	g := NewGraph()
+	if err := readJSON(r, intBuilder{&g}); err != nil {
+		return g, err
+	}
+	return g, g.validate()
+}
+
+// ReadJSONMapped reads a graph in the JSON adjacency format
+func ReadJSONMapped(r io.Reader) (MappedGraph[string], error) {
+	mg := NewMappedGraph[string]()
+	if err := readJSON(r, mappedBuilder{&mg}); err != nil {
+		return mg, err
+	}
+	return mg, mg.validate()
+}
+
+func writeJSON(w io.Writer, vertices []namedVertex) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`{"vertices":[`)
+	for i, v := range vertices {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+		vertex := jsonVertex{ID: v.name, Arcs: make([]jsonArc, 0, len(v.arcs))}
+		for _, arc := range v.arcs {
+			vertex.Arcs = append(vertex.Arcs, jsonArc{arc.to, arc.weight})
+		}
+		encoded, err := json.Marshal(vertex)
+		if err != nil {
+			return err
+		}
+		bw.WriteString("\n")
+		bw.Write(encoded)
+	}
+	bw.WriteString("\n]}\n")
+	return bw.Flush()
+}
+
+func readJSON(r io.Reader, b graphBuilder) error {
+	decoder := json.NewDecoder(r)
+	wrongFormat := func(err error) error {
+		return fmt.Errorf("%w, %w", ErrWrongFormat, err)
+	}
+	expectDelim := func(want json.Delim) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return wrongFormat(err)
+		}
+		if got, ok := token.(json.Delim); !ok || got != want {
+			return wrongFormat(fmt.Errorf("expected %v, got %v", want, token))
+		}
+		return nil
+	}
+	if err := expectDelim('{'); err != nil {
+		return err
+	}
+	foundVertices := false
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return wrongFormat(err)
+		}
+		if token != "vertices" {
+			// skip unknown fields
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return wrongFormat(err)
+			}
+			continue
+		}
+		foundVertices = true
+		if err := expectDelim('['); err != nil {
+			return err
+		}
+		for decoder.More() {
+			var vertex jsonVertex
+			if err := decoder.Decode(&vertex); err != nil {
+				return wrongFormat(err)
+			}
+			if err := b.addVertex(vertex.ID); err != nil {
+				return err
+			}
+			for _, arc := range vertex.Arcs {
+				if err := b.addArc(vertex.ID, arc.To, arc.Weight); err != nil {
+					return err
+				}
+			}
+		}
+		if err := expectDelim(']'); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim('}'); err != nil {
+		return err
+	}
+	if !foundVertices {
+		return wrongFormat(errors.New("missing vertices"))
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package dijkstra
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type graphFormat struct {
+	name        string
+	write       func(Graph, io.Writer) error
+	read        func(io.Reader) (Graph, error)
+	writeMapped func(MappedGraph[string], io.Writer) error
+	readMapped  func(io.Reader) (MappedGraph[string], error)
+}
+
+var graphFormats = []graphFormat{
+	{"DOT", Graph.WriteDOT, ReadDOT, MappedGraph[string].WriteDOT, ReadDOTMapped},
+	{"GraphML", Graph.WriteGraphML, ReadGraphML, MappedGraph[string].WriteGraphML, ReadGraphMLMapped},
+	{"JSON", Graph.WriteJSON, ReadJSON, MappedGraph[string].WriteJSON, ReadJSONMapped},
+}
+
+func TestFormatsRoundTrip(t *testing.T) {
+	graphs := []Graph{Generate(20), constrainedTestGraph(), analysisTestGraph()}
+	for _, test := range testGraphsCorrect {
+		graphs = append(graphs, test.graph)
+	}
+	withRemoved := analysisTestGraph()
+	withRemoved.RemoveVertexAndArcs(3)
+	graphs = append(graphs, withRemoved)
+
+	for _, format := range graphFormats {
+		t.Run(format.name, func(t *testing.T) {
+			for i, g := range graphs {
+				var first, second bytes.Buffer
+				if err := format.write(g, &first); err != nil {
+					t.Fatal(err)
+				}
+				got, err := format.read(bytes.NewReader(first.Bytes()))
+				testErrors(t, nil, err, i)
+				assertGraphsEqual(t, got, g, i)
+				if err := format.write(got, &second); err != nil {
+					t.Fatal(err)
+				}
+				if first.String() != second.String() {
+					t.Fatalf("output is not deterministic (test %d)\n%s\n%s", i, first.String(), second.String())
+				}
+			}
+		})
+		t.Run(format.name+"Mapped", func(t *testing.T) {
+			for i, test := range testMappedGraphs {
+				mg, _ := ImportStringMapped(test.stringRepresentation)
+				var first, second bytes.Buffer
+				if err := format.writeMapped(mg, &first); err != nil {
+					t.Fatal(err)
+				}
+				got, err := format.readMapped(bytes.NewReader(first.Bytes()))
+				testErrors(t, nil, err, i)
+				if !reflect.DeepEqual(got.mapping, mg.mapping) {
+					t.Fatal("maps are different (test", i, ")\ngot: ", got.mapping, "\nwant:", mg.mapping)
+				}
+				assertGraphsEqual(t, got.graph, mg.graph, i)
+				format.writeMapped(got, &second)
+				if first.String() != second.String() {
+					t.Fatalf("output is not deterministic (test %d)\n%s\n%s", i, first.String(), second.String())
+				}
+			}
+		})
+	}
+}
+
+func TestFormatsEscaping(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	names := []string{`quote"d`, `back\slash`, "<tag & amp>", "space d", "ünicode"}
+	for _, name := range names {
+		mg.AddEmptyVertex(name)
+	}
+	for i := 0; i < len(names)-1; i++ {
+		mg.AddArc(names[i], names[i+1], uint64(i))
+	}
+	for _, format := range graphFormats {
+		var buf bytes.Buffer
+		if err := format.writeMapped(mg, &buf); err != nil {
+			t.Fatal(err)
+		}
+		got, err := format.readMapped(&buf)
+		if err != nil {
+			t.Fatal(format.name, err)
+		}
+		if !reflect.DeepEqual(got.mapping, mg.mapping) {
+			t.Error(format.name, "names were not preserved", got.mapping)
+		}
+	}
+}
+
+func TestExportDeterministic(t *testing.T) {
+	mg, _ := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	first, _ := mg.Export()
+	for range 20 {
+		if got, _ := mg.Export(); got != first {
+			t.Fatal("mapped export is not deterministic\n", first, "\n", got)
+		}
+	}
+	want := `A B,4 C,2
+B C,3 D,2 E,3
+C B,1 D,4 E,5
+D F,10
+E D,1
+F D,10
+`
+	if first != want {
+		t.Error("mapped export is not ordered by index\n", first)
+	}
+	g := Generate(30)
+	firstGraph, _ := g.Export()
+	for range 20 {
+		if got, _ := g.Export(); got != firstGraph {
+			t.Fatal("export is not deterministic")
+		}
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+	input := `/* network */
+strict graph net {
+	graph [rankdir=LR];
+	node [shape=circle]
+	# the core
+	A -- B -- C [weight=3, color="red"];
+	"D" -- C // default weight
+	E;
+	label = "test"
+}`
+	mg, err := ReadDOTMapped(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]uint64{
+		"A": {"B": 3},
+		"B": {"A": 3, "C": 3},
+		"C": {"B": 3, "D": 1},
+		"D": {"C": 1},
+		"E": {},
+	}
+	for from, arcs := range want {
+		got, err := mg.GetVertexArcs(from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, arcs) {
+			t.Errorf("%s arcs %v, want %v", from, got, arcs)
+		}
+	}
+	g, err := ReadDOT(strings.NewReader("digraph { 0 -> 2 [weight=5] }"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGraphsEqual(t, g, Graph{[]map[int]uint64{{2: 5}, nil, {}}}, 0)
+
+	wrong := []string{
+		"",
+		"tree { A }",
+		"digraph { A -- B }",
+		"graph { A -> B }",
+		"digraph { A -> B [weight=-1] }",
+		"digraph { A -> B [weight] }",
+		"digraph { A -> B ",
+		`digraph { "A }`,
+		"digraph { subgraph x { A } }",
+	}
+	for i, input := range wrong {
+		_, err := ReadDOTMapped(strings.NewReader(input))
+		testErrors(t, ErrWrongFormat, err, i)
+	}
+	_, err = ReadDOT(strings.NewReader("digraph { A -> 0 }"))
+	testErrors(t, ErrWrongFormat, err, 0)
+}
+
+func TestReadGraphML(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="weight" attr.type="long"/>
+  <key id="d1" for="edge" attr.name="weight" attr.type="long"/>
+  <key id="d2" for="edge" attr.name="color" attr.type="string"/>
+  <graph id="G" edgedefault="undirected">
+    <node id="A"><data key="d0">99</data></node>
+    <node id="B"/>
+    <edge source="A" target="B"><data key="d1">7</data><data key="d2">12</data></edge>
+    <edge source="B" target="C" directed="true"/>
+  </graph>
+</graphml>`
+	mg, err := ReadGraphMLMapped(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]uint64{
+		"A": {"B": 7},
+		"B": {"A": 7, "C": 1},
+		"C": {},
+	}
+	for from, arcs := range want {
+		if got, _ := mg.GetVertexArcs(from); !reflect.DeepEqual(got, arcs) {
+			t.Errorf("%s arcs %v, want %v", from, got, arcs)
+		}
+	}
+	wrong := []string{
+		"",
+		"<graphml></graphml>",
+		`<graphml><graph><node/></graph></graphml>`,
+		`<graphml><graph><edge source="A"/></graph></graphml>`,
+		`<graphml><key id="w" for="edge" attr.name="weight"/><graph><edge source="A" target="B"><data key="w">x</data></edge></graph></graphml>`,
+		`<graphml><graph>`,
+	}
+	for i, input := range wrong {
+		_, err := ReadGraphMLMapped(strings.NewReader(input))
+		testErrors(t, ErrWrongFormat, err, i)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	input := `{"name": "net", "vertices": [
+		{"id": "A", "arcs": [{"to": "B", "weight": 2}], "extra": true},
+		{"id": "C"}
+	]}`
+	mg, err := ReadJSONMapped(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mg.mapping, map[string]int{"A": 0, "B": 1, "C": 2}) {
+		t.Error("wrong mapping", mg.mapping)
+	}
+	if arc, err := mg.GetArc("A", "B"); err != nil || arc != 2 {
+		t.Error("wrong arc", arc, err)
+	}
+	wrong := []string{
+		"",
+		"[]",
+		`{"name": "net"}`,
+		`{"vertices": {}}`,
+		`{"vertices": [{"id": "A", "arcs": [{"to": "B", "weight": -1}]}]}`,
+		`{"vertices": [`,
+	}
+	for i, input := range wrong {
+		_, err := ReadJSONMapped(strings.NewReader(input))
+		testErrors(t, ErrWrongFormat, err, i)
+	}
+}
@@ -14,6 +14,22 @@ func NewGraph() Graph {
 	return new
 }
 
+// Clone returns a deep copy of the graph, changes to the copy do not affect
+// the original
+func (g Graph) Clone() Graph {
+	clone := Graph{vertexArcs: make([]map[int]uint64, len(g.vertexArcs))}
+	for i, arcs := range g.vertexArcs {
+		if arcs == nil {
+			continue
+		}
+		clone.vertexArcs[i] = make(map[int]uint64, len(arcs))
+		for to, dist := range arcs {
+			clone.vertexArcs[i][to] = dist
+		}
+	}
+	return clone
+}
+
 // AddNewVertex adds a new vertex at the next available index
 func (g *Graph) AddNewEmptyVertex() (index int) {
 	for i, v := range g.vertexArcs {
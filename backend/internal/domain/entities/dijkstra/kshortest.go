@@ -0,0 +1,91 @@
+package dijkstra
+
+import (
+	"cmp"
+	"slices"
+)
+
+// KShortest calculates up to k loopless paths from src to dest, shortest
+// first, with Yen's algorithm. Paths of the same distance come fewer hops
+// first.
+func (g Graph) KShortest(src, dest, k int) ([]BestPath[int], error) {
+	if err := g.searchValid(src, dest); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return []BestPath[int]{}, nil
+	}
+	first, err := g.ShortestConstrained(src, dest, Constraints[int]{})
+	if err != nil {
+		return nil, err
+	}
+	found := []BestPath[int]{first}
+	var candidates []BestPath[int]
+	known := func(path []int) bool {
+		same := func(bp BestPath[int]) bool { return slices.Equal(bp.Path, path) }
+		return slices.ContainsFunc(found, same) || slices.ContainsFunc(candidates, same)
+	}
+	for len(found) < k {
+		previous := found[len(found)-1].Path
+		rootDistance := uint64(0)
+		for i := 0; i < len(previous)-1; i++ {
+			spur, root := previous[i], previous[:i+1]
+			// the next arc of every path found with the same root is taken out,
+			// so the spur path has to leave the root some other way
+			var excludeArcs []Arc[int]
+			for _, bp := range found {
+				if len(bp.Path) > i+1 && slices.Equal(bp.Path[:i+1], root) {
+					excludeArcs = append(excludeArcs, Arc[int]{bp.Path[i], bp.Path[i+1]})
+				}
+			}
+			spurPath, err := g.ShortestConstrained(spur, dest, Constraints[int]{
+				ExcludeVertices: previous[:i],
+				ExcludeArcs:     excludeArcs,
+			})
+			if err == nil {
+				path := append(slices.Clone(previous[:i]), spurPath.Path...)
+				if !known(path) {
+					candidates = append(candidates, BestPath[int]{saturatingAdd(rootDistance, spurPath.Distance), path})
+				}
+			}
+			rootDistance = saturatingAdd(rootDistance, g.vertexArcs[spur][previous[i+1]])
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for i, candidate := range candidates {
+			if cmp.Or(
+				cmp.Compare(candidate.Distance, candidates[best].Distance),
+				cmp.Compare(len(candidate.Path), len(candidates[best].Path)),
+			) < 0 {
+				best = i
+			}
+		}
+		found = append(found, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+	return found, nil
+}
+
+// KShortest calculates up to k loopless paths from src to dest, shortest
+// first
+func (mg MappedGraph[T]) KShortest(src, dest T, k int) ([]BestPath[T], error) {
+	srcID, destID, err := mg.getMap2(src, dest)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := mg.graph.KShortest(srcID, destID, k)
+	if err != nil {
+		return nil, mg.toMappedErr(err)
+	}
+	mapped := make([]BestPath[T], 0, len(paths))
+	for _, bp := range paths {
+		mappedPath, err := mg.toMappedBestPath(bp)
+		if err != nil {
+			return nil, err
+		}
+		mapped = append(mapped, mappedPath)
+	}
+	return mapped, nil
+}
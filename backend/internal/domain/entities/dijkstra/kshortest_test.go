@@ -0,0 +1,96 @@
+package dijkstra
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// simplePathDistances returns the distance of every loopless path from src to
+// dest, sorted
+func simplePathDistances(g Graph, src, dest int) []uint64 {
+	var distances []uint64
+	visited := make([]bool, len(g.vertexArcs))
+	var visit func(v int, distance uint64)
+	visit = func(v int, distance uint64) {
+		if v == dest {
+			distances = append(distances, distance)
+			return
+		}
+		visited[v] = true
+		for to, dist := range g.vertexArcs[v] {
+			if !visited[to] {
+				visit(to, distance+dist)
+			}
+		}
+		visited[v] = false
+	}
+	visit(src, 0)
+	slices.Sort(distances)
+	return distances
+}
+
+func TestKShortest(t *testing.T) {
+	g := constrainedTestGraph()
+	paths, err := g.KShortest(0, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BestPath[int]{
+		{4, []int{0, 1, 2, 3, 4}},
+		{6, []int{0, 2, 3, 4}},
+		{7, []int{0, 1, 3, 4}},
+	}
+	if len(paths) != len(want) {
+		t.Fatal("wrong amount of paths", paths)
+	}
+	for i := range want {
+		if paths[i].Distance != want[i].Distance || !slices.Equal(paths[i].Path, want[i].Path) {
+			t.Fatal("wrong path", i, paths[i], want[i])
+		}
+	}
+	if _, err := g.KShortest(4, 0, 3); !errors.Is(err, ErrNoPath) {
+		t.Fatal("expected no path", err)
+	}
+}
+
+func TestKShortestRandom(t *testing.T) {
+	for gi, g := range []Graph{Generate(8), Generate(12), constrainedTestGraph()} {
+		seeded := rand.New(rand.NewSource(int64(gi)))
+		for i := range 20 {
+			src, dest := seeded.Intn(len(g.vertexArcs)), seeded.Intn(len(g.vertexArcs))
+			if src == dest {
+				continue
+			}
+			want := simplePathDistances(g, src, dest)
+			k := 1 + seeded.Intn(6)
+			paths, err := g.KShortest(src, dest, k)
+			if len(want) == 0 {
+				if !errors.Is(err, ErrNoPath) {
+					t.Fatal("expected no path", gi, i, src, dest, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(paths) != min(k, len(want)) {
+				t.Fatal("wrong amount of paths", gi, i, len(paths), k, len(want))
+			}
+			for j, bp := range paths {
+				if bp.Distance != want[j] {
+					t.Fatal("wrong distance", gi, i, j, bp, want[:len(paths)])
+				}
+				if bp.Path[0] != src || bp.Path[len(bp.Path)-1] != dest {
+					t.Fatal("path does not join src and dest", gi, i, bp)
+				}
+				for _, other := range paths[:j] {
+					if slices.Equal(other.Path, bp.Path) {
+						t.Fatal("path found twice", gi, i, bp)
+					}
+				}
+			}
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package dijkstra
 
-import "errors"
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
 
 type MappedGraph[T comparable] struct {
 	graph   Graph
@@ -15,6 +19,15 @@ func NewMappedGraph[T comparable]() MappedGraph[T] {
 	}
 }
 
+// Clone returns a deep copy of the mapped graph
+func (mg MappedGraph[T]) Clone() MappedGraph[T] {
+	mapping := make(map[T]int, len(mg.mapping))
+	for k, v := range mg.mapping {
+		mapping[k] = v
+	}
+	return MappedGraph[T]{graph: mg.graph.Clone(), mapping: mapping}
+}
+
 // AddEmptyVertex adds a single empty vertex
 func (mg *MappedGraph[T]) AddEmptyVertex(item T) error {
 	_, err := mg.addMap(item)
@@ -105,6 +118,17 @@ func (mg MappedGraph[T]) getMap(item T) (int, error) {
 	}
 	return id, nil
 }
+func (mg MappedGraph[T]) getMaps(items []T) ([]int, error) {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		id, err := mg.getMap(item)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
 func (mg MappedGraph[T]) getInverseMap(index int) (T, error) {
 	for k, v := range mg.mapping {
 		if v == index {
@@ -115,6 +139,18 @@ func (mg MappedGraph[T]) getInverseMap(index int) (T, error) {
 	return t, newErrMapNotFound(index)
 }
 
+// orderedVertices returns the mapped vertices ordered by their index
+func (mg MappedGraph[T]) orderedVertices() []T {
+	vertices := make([]T, 0, len(mg.mapping))
+	for k := range mg.mapping {
+		vertices = append(vertices, k)
+	}
+	slices.SortFunc(vertices, func(a, b T) int {
+		return cmp.Compare(mg.mapping[a], mg.mapping[b])
+	})
+	return vertices
+}
+
 func (mg *MappedGraph[T]) addMap(item T) (int, error) {
 	var id int
 	var ok bool
@@ -159,20 +195,32 @@ func (mg *MappedGraph[T]) GetVertexArcs(item T) (map[T]uint64, error) {
 	return mg.getInverseMappedMap(arcs)
 }
 
+// RemoveVertexAndArcs removes the vertex and all arcs pointing to it, the
+// item can be added again afterwards
 func (mg *MappedGraph[T]) RemoveVertexAndArcs(item T) error {
 	index, err := mg.getMap(item)
 	if err != nil {
 		return err
 	}
-	return mg.graph.RemoveVertexAndArcs(index)
+	if err = mg.graph.RemoveVertexAndArcs(index); err != nil {
+		return err
+	}
+	delete(mg.mapping, item)
+	return nil
 }
 
+// RemoveVertex removes the vertex, fails if there are still arcs pointing to
+// it
 func (mg *MappedGraph[T]) RemoveVertex(item T) error {
 	index, err := mg.getMap(item)
 	if err != nil {
 		return err
 	}
-	return mg.graph.RemoveVertex(index)
+	if err = mg.graph.RemoveVertex(index); err != nil {
+		return err
+	}
+	delete(mg.mapping, item)
+	return nil
 }
 
 func (mg MappedGraph[T]) getInverseMappedMap(arcs map[int]uint64) (map[T]uint64, error) {
@@ -272,7 +320,7 @@ func (mg MappedGraph[T]) evaluate(src, dest T, shortest bool) (BestPath[T], erro
 		bpOriginal, err = mg.graph.Longest(srcId, destId)
 	}
 	if err != nil {
-		return bp, err
+		return bp, mg.toMappedErr(err)
 	}
 	return mg.toMappedBestPath(bpOriginal)
 }
@@ -298,7 +346,7 @@ func (mg MappedGraph[T]) evaluateAll(src, dest T, shortest bool) (BestPaths[T],
 		bpOriginal, err = mg.graph.LongestAll(srcId, destId)
 	}
 	if err != nil {
-		return bp, err
+		return bp, mg.toMappedErr(err)
 	}
 	return mg.toMappedBestPaths(bpOriginal)
 }
@@ -0,0 +1,238 @@
+package dijkstra
+
+// SearchAlgorithm selects how the shortest path between two vertices is
+// searched
+type SearchAlgorithm int
+
+const (
+	// SearchDijkstra is the search used by Shortest
+	SearchDijkstra SearchAlgorithm = iota
+	// SearchAStar expands the vertices that look closer to dest first
+	SearchAStar
+	// SearchBidirectional searches from src and dest at the same time
+	SearchBidirectional
+)
+
+// SearchStats tells how much of the graph a search explored
+type SearchStats struct {
+	// Expanded is the amount of vertices taken out of the list, including
+	// outdated entries
+	Expanded int
+}
+
+// countingList counts the vertices taken out of a list
+type countingList struct {
+	dijkstraList
+	popped int
+}
+
+func (l *countingList) PopOrdered() currentDistance {
+	l.popped++
+	return l.dijkstraList.PopOrdered()
+}
+
+// ShortestStats calculates the shortest path from src to dest like Shortest
+// and tells how many vertices were expanded
+func (g Graph) ShortestStats(src, dest int) (BestPath[int], SearchStats, error) {
+	visiting := &countingList{dijkstraList: g.getList(listShortAuto)}
+	best, err := g.evaluateList(src, dest, true, visiting)
+	return best, SearchStats{visiting.popped}, err
+}
+
+// ShortestAStar calculates the shortest path from src to dest, expanding first
+// the vertices with the smallest distance from src plus the estimated distance
+// to dest. The heuristic must never be greater than the real shortest distance
+// from a vertex to dest, otherwise the path found may not be the shortest.
+func (g Graph) ShortestAStar(src, dest int, heuristic func(v int) uint64) (BestPath[int], SearchStats, error) {
+	var stats SearchStats
+	if err := g.searchValid(src, dest); err != nil {
+		return BestPath[int]{}, stats, err
+	}
+	distances := make([]uint64, len(g.vertexArcs))
+	previous := make([]int, len(g.vertexArcs))
+	for i := range distances {
+		distances[i] = Infinity
+		previous[i] = -1
+	}
+	distances[src] = 0
+	visiting := g.getList(listShortPQ)
+	visiting.PushOrdered(currentDistance{src, heuristic(src)})
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		stats.Expanded++
+		if current.distance > saturatingAdd(distances[current.id], heuristic(current.id)) {
+			continue
+		}
+		if current.id == dest {
+			return BestPath[int]{distances[dest], buildPath(previous, src, dest)}, stats, nil
+		}
+		for to, dist := range g.vertexArcs[current.id] {
+			distance := distances[current.id] + dist
+			if distance < distances[current.id] {
+				// overflow
+				continue
+			}
+			if distance < distances[to] {
+				distances[to] = distance
+				previous[to] = current.id
+				visiting.PushOrdered(currentDistance{to, saturatingAdd(distance, heuristic(to))})
+			}
+		}
+	}
+	return BestPath[int]{}, stats, newErrNoPath(src, dest)
+}
+
+// ShortestBidirectional calculates the shortest path from src to dest running
+// Dijkstra forward from src and backward from dest, alternating between them
+// until the searches meet. The reversed arcs are built on every call.
+func (g Graph) ShortestBidirectional(src, dest int) (BestPath[int], SearchStats, error) {
+	var stats SearchStats
+	if err := g.searchValid(src, dest); err != nil {
+		return BestPath[int]{}, stats, err
+	}
+	arcs := [2][]map[int]uint64{g.vertexArcs, g.incomingArcs()}
+	dist := [2][]uint64{make([]uint64, len(g.vertexArcs)), make([]uint64, len(g.vertexArcs))}
+	// prev is the previous vertex going forward and the next one going
+	// backward
+	prev := [2][]int{make([]int, len(g.vertexArcs)), make([]int, len(g.vertexArcs))}
+	settled := [2][]bool{make([]bool, len(g.vertexArcs)), make([]bool, len(g.vertexArcs))}
+	for side := range 2 {
+		for i := range dist[side] {
+			dist[side][i] = Infinity
+			prev[side][i] = -1
+		}
+	}
+	dist[0][src], dist[1][dest] = 0, 0
+	lists := [2]dijkstraList{g.getList(listShortPQ), g.getList(listShortPQ)}
+	lists[0].PushOrdered(currentDistance{src, 0})
+	lists[1].PushOrdered(currentDistance{dest, 0})
+	var last [2]uint64
+	best, meet := uint64(Infinity), -1
+	for side := 0; lists[0].Len() > 0 && lists[1].Len() > 0; side = 1 - side {
+		current := lists[side].PopOrdered()
+		stats.Expanded++
+		if settled[side][current.id] || current.distance > dist[side][current.id] {
+			continue
+		}
+		settled[side][current.id] = true
+		last[side] = current.distance
+		if saturatingAdd(last[0], last[1]) >= best {
+			break
+		}
+		for to, d := range arcs[side][current.id] {
+			distance := current.distance + d
+			if distance < current.distance {
+				// overflow
+				continue
+			}
+			if distance < dist[side][to] {
+				dist[side][to] = distance
+				prev[side][to] = current.id
+				lists[side].PushOrdered(currentDistance{to, distance})
+			}
+			if total := saturatingAdd(dist[side][to], dist[1-side][to]); total < best {
+				best, meet = total, to
+			}
+		}
+	}
+	if meet == -1 {
+		return BestPath[int]{}, stats, newErrNoPath(src, dest)
+	}
+	path := buildPath(prev[0], src, meet)
+	for v := prev[1][meet]; v != -1; v = prev[1][v] {
+		path = append(path, v)
+	}
+	return BestPath[int]{best, path}, stats, nil
+}
+
+// searchValid checks the vertices of a search, like Shortest there is no path
+// from a vertex to itself
+func (g Graph) searchValid(src, dest int) error {
+	if err := g.vertexValid(src); err != nil {
+		return err
+	}
+	if err := g.vertexValid(dest); err != nil {
+		return err
+	}
+	if src == dest {
+		return newErrNoPath(src, dest)
+	}
+	return nil
+}
+
+// incomingArcs returns the arcs of the graph reversed
+func (g Graph) incomingArcs() []map[int]uint64 {
+	incoming := make([]map[int]uint64, len(g.vertexArcs))
+	for from, arcs := range g.vertexArcs {
+		if arcs != nil && incoming[from] == nil {
+			incoming[from] = map[int]uint64{}
+		}
+		for to, dist := range arcs {
+			if incoming[to] == nil {
+				incoming[to] = map[int]uint64{}
+			}
+			incoming[to][from] = dist
+		}
+	}
+	return incoming
+}
+
+// buildPath follows previous back from dest to src
+func buildPath(previous []int, src, dest int) []int {
+	var path []int
+	for c := dest; c != src; c = previous[c] {
+		path = append(path, c)
+	}
+	path = append(path, src)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a+b < a {
+		return Infinity
+	}
+	return a + b
+}
+
+// SearchOptions selects how MappedGraph.ShortestSearch finds a path
+type SearchOptions[T comparable] struct {
+	Algorithm SearchAlgorithm
+	// Heuristic is only used by A*, it estimates the distance from item to
+	// dest and must never be greater than the real shortest distance. A* with
+	// no heuristic expands vertices like Dijkstra.
+	Heuristic func(item, dest T) uint64
+}
+
+// ShortestSearch calculates the shortest path from src to dest with the
+// selected algorithm and tells how many vertices were expanded
+func (mg MappedGraph[T]) ShortestSearch(src, dest T, options SearchOptions[T]) (BestPath[T], SearchStats, error) {
+	srcID, destID, err := mg.getMap2(src, dest)
+	if err != nil {
+		return BestPath[T]{}, SearchStats{}, err
+	}
+	var best BestPath[int]
+	var stats SearchStats
+	switch options.Algorithm {
+	case SearchAStar:
+		heuristic := func(int) uint64 { return 0 }
+		if options.Heuristic != nil {
+			vertices := mg.indexedVertices()
+			heuristic = func(v int) uint64 {
+				return options.Heuristic(vertices[v], dest)
+			}
+		}
+		best, stats, err = mg.graph.ShortestAStar(srcID, destID, heuristic)
+	case SearchBidirectional:
+		best, stats, err = mg.graph.ShortestBidirectional(srcID, destID)
+	default:
+		best, stats, err = mg.graph.ShortestStats(srcID, destID)
+	}
+	if err != nil {
+		return BestPath[T]{}, stats, err
+	}
+	mapped, err := mg.toMappedBestPath(best)
+	return mapped, stats, err
+}
@@ -0,0 +1,169 @@
+package dijkstra
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// gridTestGraph returns a side x side grid with arcs to the 4 neighbours of
+// every cell. Arcs cost at least 1000 per cell so the euclidean heuristic
+// never overestimates.
+func gridTestGraph(side int) (Graph, func(v, dest int) uint64) {
+	seeded := rand.New(rand.NewSource(int64(side)))
+	g := Graph{make([]map[int]uint64, side*side)}
+	for v := range g.vertexArcs {
+		g.vertexArcs[v] = map[int]uint64{}
+	}
+	for y := 0; y < side; y++ {
+		for x := 0; x < side; x++ {
+			v := y*side + x
+			if x+1 < side {
+				g.vertexArcs[v][v+1] = 1000 + uint64(seeded.Intn(500))
+				g.vertexArcs[v+1][v] = 1000 + uint64(seeded.Intn(500))
+			}
+			if y+1 < side {
+				g.vertexArcs[v][v+side] = 1000 + uint64(seeded.Intn(500))
+				g.vertexArcs[v+side][v] = 1000 + uint64(seeded.Intn(500))
+			}
+		}
+	}
+	euclidean := func(v, dest int) uint64 {
+		dx, dy := float64(v%side-dest%side), float64(v/side-dest/side)
+		return uint64(1000 * math.Sqrt(dx*dx+dy*dy))
+	}
+	return g, euclidean
+}
+
+func TestSearchAlgorithms(t *testing.T) {
+	g, euclidean := gridTestGraph(20)
+	graphs := []Graph{g, Generate(50), Generate(200), constrainedTestGraph()}
+	for gi, g := range graphs {
+		seeded := rand.New(rand.NewSource(int64(gi)))
+		for i := range 50 {
+			src, dest := seeded.Intn(len(g.vertexArcs)), seeded.Intn(len(g.vertexArcs))
+			want, wantErr := g.Shortest(src, dest)
+			heuristic := func(int) uint64 { return 0 }
+			if gi == 0 {
+				heuristic = func(v int) uint64 { return euclidean(v, dest) }
+			}
+			aStar, _, err := g.ShortestAStar(src, dest, heuristic)
+			if (err == nil) != (wantErr == nil) || aStar.Distance != want.Distance {
+				t.Fatal("a* differs from dijkstra", gi, i, src, dest, aStar, want, err, wantErr)
+			}
+			bidirectional, _, err := g.ShortestBidirectional(src, dest)
+			if (err == nil) != (wantErr == nil) || bidirectional.Distance != want.Distance {
+				t.Fatal("bidirectional differs from dijkstra", gi, i, src, dest, bidirectional, want, err, wantErr)
+			}
+			if err != nil {
+				continue
+			}
+			for _, path := range [][]int{aStar.Path, bidirectional.Path} {
+				if path[0] != src || path[len(path)-1] != dest {
+					t.Fatal("path does not join src and dest", path)
+				}
+				var distance uint64
+				for j := 0; j < len(path)-1; j++ {
+					arc, err := g.GetArc(path[j], path[j+1])
+					testErrors(t, nil, err, i)
+					distance += arc
+				}
+				if distance != want.Distance {
+					t.Fatal("path distance does not match", path, distance, want.Distance)
+				}
+			}
+		}
+	}
+}
+
+func TestSearchExpandsLess(t *testing.T) {
+	g, euclidean := gridTestGraph(40)
+	src, dest := 20*40+10, 20*40+30
+	_, dijkstra, err := g.ShortestStats(src, dest)
+	testErrors(t, nil, err, 0)
+	_, aStar, err := g.ShortestAStar(src, dest, func(v int) uint64 { return euclidean(v, dest) })
+	testErrors(t, nil, err, 1)
+	if aStar.Expanded >= dijkstra.Expanded {
+		t.Error("a* should expand less vertices than dijkstra", aStar.Expanded, dijkstra.Expanded)
+	}
+	// from the middle a bidirectional search covers two small discs
+	src = 20*40 + 20
+	_, dijkstra, _ = g.ShortestStats(src, src+5)
+	_, bidirectional, err := g.ShortestBidirectional(src, src+5)
+	testErrors(t, nil, err, 2)
+	if bidirectional.Expanded >= dijkstra.Expanded {
+		t.Error("bidirectional should expand less vertices than dijkstra", bidirectional.Expanded, dijkstra.Expanded)
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	g := constrainedTestGraph()
+	_, _, err := g.ShortestAStar(0, 0, func(int) uint64 { return 0 })
+	testErrors(t, ErrNoPath, err, 0)
+	_, _, err = g.ShortestBidirectional(0, 99)
+	testErrors(t, ErrVertexNotFound, err, 1)
+	g.RemoveVertexAndArcs(1)
+	_, _, err = g.ShortestBidirectional(0, 1)
+	testErrors(t, ErrVertexNotFound, err, 2)
+}
+
+func TestMappedShortestSearch(t *testing.T) {
+	mg, _ := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	want, _ := mg.Shortest("A", "F")
+	for _, algorithm := range []SearchAlgorithm{SearchDijkstra, SearchAStar, SearchBidirectional} {
+		got, stats, err := mg.ShortestSearch("A", "F", SearchOptions[string]{
+			Algorithm: algorithm,
+			Heuristic: func(item, dest string) uint64 { return 0 },
+		})
+		testErrors(t, nil, err, int(algorithm))
+		if got.Distance != want.Distance || stats.Expanded == 0 {
+			t.Error("wrong search result", algorithm, got, stats)
+		}
+	}
+	_, _, err := mg.ShortestSearch("A", "Z", SearchOptions[string]{Algorithm: SearchAStar})
+	testErrors(t, ErrVertexNotFound, err, 0)
+}
+
+// BenchmarkSearch compares the vertices expanded by every search, reported as
+// expanded/op next to the time taken
+func BenchmarkSearch(b *testing.B) {
+	for _, side := range []int{10, 30, 100} {
+		g, euclidean := gridTestGraph(side)
+		// across the middle of the grid, so searches can miss the corners
+		src, dest := side/2*side+side/4, side/2*side+side*3/4
+		heuristic := func(v int) uint64 { return euclidean(v, dest) }
+		searches := []struct {
+			name   string
+			search func() SearchStats
+		}{
+			{"listShortLL", func() SearchStats {
+				visiting := &countingList{dijkstraList: g.getList(listShortLL)}
+				g.evaluateList(src, dest, true, visiting)
+				return SearchStats{visiting.popped}
+			}},
+			{"listShortPQ", func() SearchStats {
+				visiting := &countingList{dijkstraList: g.getList(listShortPQ)}
+				g.evaluateList(src, dest, true, visiting)
+				return SearchStats{visiting.popped}
+			}},
+			{"AStar", func() SearchStats {
+				_, stats, _ := g.ShortestAStar(src, dest, heuristic)
+				return stats
+			}},
+			{"Bidirectional", func() SearchStats {
+				_, stats, _ := g.ShortestBidirectional(src, dest)
+				return stats
+			}},
+		}
+		for _, search := range searches {
+			b.Run(strconv.Itoa(side*side)+"Nodes/"+search.name, func(b *testing.B) {
+				var stats SearchStats
+				for i := 0; i < b.N; i++ {
+					stats = search.search()
+				}
+				b.ReportMetric(float64(stats.Expanded), "expanded/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package dijkstra
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Edge is an arc whose direction is ignored
+type Edge[T comparable] struct {
+	Src    T
+	Dest   T
+	Weight uint64
+}
+
+// Tree is a set of edges without cycles, Weight is the sum of their weights
+type Tree[T comparable] struct {
+	Edges  []Edge[T]
+	Weight uint64
+}
+
+// PrimMST returns the minimum spanning forest of the graph with arc directions
+// ignored, built with Prim's algorithm. Two vertices are joined by the
+// shortest arc between them in either direction, Src is always the smaller
+// vertex and edges are sorted.
+func (g Graph) PrimMST() Tree[int] {
+	return g.undirected().prim()
+}
+
+// KruskalMST returns the minimum spanning forest of the graph with arc
+// directions ignored, built with Kruskal's algorithm. The forest has the same
+// weight as the one of PrimMST, edges are sorted the same way.
+func (g Graph) KruskalMST() Tree[int] {
+	u := g.undirected()
+	var edges []Edge[int]
+	for from, arcs := range u.vertexArcs {
+		for to, weight := range arcs {
+			if from < to {
+				edges = append(edges, Edge[int]{from, to, weight})
+			}
+		}
+	}
+	slices.SortFunc(edges, func(a, b Edge[int]) int {
+		return cmp.Or(cmp.Compare(a.Weight, b.Weight), cmp.Compare(a.Src, b.Src), cmp.Compare(a.Dest, b.Dest))
+	})
+	sets := newDisjointSets(len(u.vertexArcs))
+	var tree Tree[int]
+	for _, edge := range edges {
+		if sets.union(edge.Src, edge.Dest) {
+			tree.add(edge)
+		}
+	}
+	sortEdges(tree.Edges)
+	return tree
+}
+
+// SteinerTree returns a tree that connects every terminal, with arc directions
+// ignored, whose weight is at most twice the weight of the optimal one
+// (Kou, Markowsky and Berman). Vertices that are not terminals are only used
+// to join terminals.
+func (g Graph) SteinerTree(terminals []int) (Tree[int], error) {
+	for _, terminal := range terminals {
+		if err := g.vertexValid(terminal); err != nil {
+			return Tree[int]{}, err
+		}
+	}
+	terminals = slices.Clone(terminals)
+	slices.Sort(terminals)
+	terminals = slices.Compact(terminals)
+	if len(terminals) < 2 {
+		return Tree[int]{}, nil
+	}
+	u := g.undirected()
+
+	// minimum spanning tree of the distance graph between terminals
+	trees := make([]shortestTree, len(terminals))
+	for i, terminal := range terminals {
+		trees[i] = u.shortestFrom(terminal)
+	}
+	closure := Graph{make([]map[int]uint64, len(terminals))}
+	for i := range terminals {
+		closure.vertexArcs[i] = map[int]uint64{}
+		for j, terminal := range terminals {
+			if i == j {
+				continue
+			}
+			if trees[i].distances[terminal] == Infinity {
+				return Tree[int]{}, newErrNoPath(terminals[i], terminal)
+			}
+			closure.vertexArcs[i][j] = trees[i].distances[terminal]
+		}
+	}
+
+	// every closure edge is replaced by its shortest path
+	sub := Graph{make([]map[int]uint64, len(u.vertexArcs))}
+	for _, edge := range closure.prim().Edges {
+		tree := trees[edge.Src]
+		for v := terminals[edge.Dest]; v != terminals[edge.Src]; v = tree.previous[v] {
+			from := tree.previous[v]
+			for _, vertex := range []int{from, v} {
+				if sub.vertexArcs[vertex] == nil {
+					sub.vertexArcs[vertex] = map[int]uint64{}
+				}
+			}
+			sub.vertexArcs[from][v] = u.vertexArcs[from][v]
+			sub.vertexArcs[v][from] = u.vertexArcs[from][v]
+		}
+	}
+
+	tree := sub.prim()
+	pruneTree(&tree, terminals)
+	return tree, nil
+}
+
+// undirected returns a graph with an arc in each direction between every pair
+// of adjacent vertices, weighted by the shortest arc between them. Loops are
+// dropped.
+func (g Graph) undirected() Graph {
+	u := Graph{make([]map[int]uint64, len(g.vertexArcs))}
+	for from, arcs := range g.vertexArcs {
+		if arcs != nil {
+			u.vertexArcs[from] = map[int]uint64{}
+		}
+	}
+	for from, arcs := range g.vertexArcs {
+		for to, weight := range arcs {
+			if to == from || to >= len(u.vertexArcs) || u.vertexArcs[to] == nil {
+				continue
+			}
+			if old, ok := u.vertexArcs[from][to]; !ok || weight < old {
+				u.vertexArcs[from][to] = weight
+				u.vertexArcs[to][from] = weight
+			}
+		}
+	}
+	return u
+}
+
+// prim expects a graph with an arc in each direction between adjacent
+// vertices. Neighbours are visited in order so ties are broken the same way on
+// every call.
+func (u Graph) prim() Tree[int] {
+	adjacency := u.sortedAdjacency()
+	inTree := make([]bool, len(u.vertexArcs))
+	parent := make([]int, len(u.vertexArcs))
+	key := make([]uint64, len(u.vertexArcs))
+	for i := range key {
+		key[i] = Infinity
+	}
+	var tree Tree[int]
+	for root, v := range u.vertexArcs {
+		if v == nil || inTree[root] {
+			continue
+		}
+		key[root] = 0
+		parent[root] = -1
+		visiting := u.getList(listShortPQ)
+		visiting.PushOrdered(currentDistance{root, 0})
+		for visiting.Len() > 0 {
+			current := visiting.PopOrdered()
+			if inTree[current.id] || current.distance > key[current.id] {
+				continue
+			}
+			inTree[current.id] = true
+			if parent[current.id] != -1 {
+				from := parent[current.id]
+				tree.add(Edge[int]{min(from, current.id), max(from, current.id), current.distance})
+			}
+			for _, to := range adjacency[current.id] {
+				weight := u.vertexArcs[current.id][to]
+				if !inTree[to] && weight < key[to] {
+					key[to] = weight
+					parent[to] = current.id
+					visiting.PushOrdered(currentDistance{to, weight})
+				}
+			}
+		}
+	}
+	sortEdges(tree.Edges)
+	return tree
+}
+
+func (t *Tree[T]) add(edge Edge[T]) {
+	t.Edges = append(t.Edges, edge)
+	t.Weight += edge.Weight
+}
+
+func sortEdges(edges []Edge[int]) {
+	slices.SortFunc(edges, func(a, b Edge[int]) int {
+		return cmp.Or(cmp.Compare(a.Src, b.Src), cmp.Compare(a.Dest, b.Dest))
+	})
+}
+
+// pruneTree removes, one at a time, the leaves that are not terminals
+func pruneTree(t *Tree[int], terminals []int) {
+	keep := map[int]bool{}
+	for _, terminal := range terminals {
+		keep[terminal] = true
+	}
+	for {
+		degree := map[int]int{}
+		for _, edge := range t.Edges {
+			degree[edge.Src]++
+			degree[edge.Dest]++
+		}
+		pruned := Tree[int]{}
+		for _, edge := range t.Edges {
+			if (degree[edge.Src] == 1 && !keep[edge.Src]) || (degree[edge.Dest] == 1 && !keep[edge.Dest]) {
+				continue
+			}
+			pruned.add(edge)
+		}
+		if len(pruned.Edges) == len(t.Edges) {
+			return
+		}
+		*t = pruned
+	}
+}
+
+// disjointSets is a union find over the vertices of a graph
+type disjointSets struct {
+	parent []int
+	rank   []int
+}
+
+func newDisjointSets(n int) disjointSets {
+	sets := disjointSets{make([]int, n), make([]int, n)}
+	for i := range sets.parent {
+		sets.parent[i] = i
+	}
+	return sets
+}
+
+func (s disjointSets) find(v int) int {
+	for s.parent[v] != v {
+		s.parent[v] = s.parent[s.parent[v]]
+		v = s.parent[v]
+	}
+	return v
+}
+
+// union joins the sets of a and b, it returns false if they were already
+// joined
+func (s disjointSets) union(a, b int) bool {
+	a, b = s.find(a), s.find(b)
+	if a == b {
+		return false
+	}
+	if s.rank[a] < s.rank[b] {
+		a, b = b, a
+	}
+	s.parent[b] = a
+	if s.rank[a] == s.rank[b] {
+		s.rank[a]++
+	}
+	return true
+}
+
+// PrimMST returns the minimum spanning forest of the graph with arc directions
+// ignored
+func (mg MappedGraph[T]) PrimMST() Tree[T] {
+	return mg.toMappedTree(mg.graph.PrimMST())
+}
+
+// KruskalMST returns the minimum spanning forest of the graph with arc
+// directions ignored
+func (mg MappedGraph[T]) KruskalMST() Tree[T] {
+	return mg.toMappedTree(mg.graph.KruskalMST())
+}
+
+// SteinerTree returns an approximate minimum tree that connects every terminal
+func (mg MappedGraph[T]) SteinerTree(terminals []T) (Tree[T], error) {
+	ids, err := mg.getMaps(terminals)
+	if err != nil {
+		return Tree[T]{}, err
+	}
+	tree, err := mg.graph.SteinerTree(ids)
+	if err != nil {
+		return Tree[T]{}, err
+	}
+	return mg.toMappedTree(tree), nil
+}
+
+func (mg MappedGraph[T]) toMappedTree(tree Tree[int]) Tree[T] {
+	vertices := mg.indexedVertices()
+	mapped := Tree[T]{Weight: tree.Weight}
+	for _, edge := range tree.Edges {
+		mapped.Edges = append(mapped.Edges, Edge[T]{vertices[edge.Src], vertices[edge.Dest], edge.Weight})
+	}
+	return mapped
+}
@@ -0,0 +1,134 @@
+package dijkstra
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := analysisTestGraph()
+	want := Tree[int]{
+		Edges: []Edge[int]{
+			{0, 1, 1},
+			{0, 2, 1},
+			{2, 3, 5},
+			{3, 4, 1},
+			{3, 5, 1},
+			{5, 6, 2},
+		},
+		Weight: 11,
+	}
+	if got := g.PrimMST(); !reflect.DeepEqual(got, want) {
+		t.Error("wrong prim tree", got)
+	}
+	if got := g.KruskalMST(); !reflect.DeepEqual(got, want) {
+		t.Error("wrong kruskal tree", got)
+	}
+
+	// the shortest direction is used and forests span every component
+	g = Graph{[]map[int]uint64{{1: 9}, {0: 2}, {3: 4}, {}, {4: 1}}}
+	want = Tree[int]{Edges: []Edge[int]{{0, 1, 2}, {2, 3, 4}}, Weight: 6}
+	if got := g.PrimMST(); !reflect.DeepEqual(got, want) {
+		t.Error("wrong prim forest", got)
+	}
+	if got := g.KruskalMST(); !reflect.DeepEqual(got, want) {
+		t.Error("wrong kruskal forest", got)
+	}
+
+	for _, n := range []int{10, 50, 200} {
+		g := Generate(n)
+		prim, kruskal := g.PrimMST(), g.KruskalMST()
+		if prim.Weight != kruskal.Weight {
+			t.Error("prim and kruskal weights differ", n, prim.Weight, kruskal.Weight)
+		}
+		components := len(g.WeaklyConnectedComponents())
+		if len(prim.Edges) != n-components || len(kruskal.Edges) != n-components {
+			t.Error("spanning forest has the wrong amount of edges", n, len(prim.Edges), len(kruskal.Edges))
+		}
+		if !reflect.DeepEqual(prim, g.PrimMST()) {
+			t.Error("prim is not deterministic", n)
+		}
+	}
+}
+
+func TestSteinerTree(t *testing.T) {
+	// a star through 4 is cheaper than joining the terminals directly
+	g := Graph{[]map[int]uint64{
+		{1: 20, 3: 10, 4: 6},
+		{2: 20, 4: 6},
+		{3: 10, 4: 6},
+		{},
+		{},
+		{0: 1},
+	}}
+	got, err := g.SteinerTree([]int{0, 1, 2})
+	testErrors(t, nil, err, 0)
+	want := Tree[int]{Edges: []Edge[int]{{0, 4, 6}, {1, 4, 6}, {2, 4, 6}}, Weight: 18}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("wrong steiner tree", got)
+	}
+
+	got, err = g.SteinerTree([]int{3, 1})
+	testErrors(t, nil, err, 1)
+	if got.Weight != 22 || len(got.Edges) != 3 {
+		t.Error("two terminals should be joined by their shortest path", got)
+	}
+
+	got, err = g.SteinerTree([]int{2, 2})
+	testErrors(t, nil, err, 2)
+	if len(got.Edges) != 0 {
+		t.Error("a single terminal needs no edges", got)
+	}
+
+	mst := analysisTestGraph().PrimMST()
+	got, err = analysisTestGraph().SteinerTree([]int{0, 1, 2, 3, 4, 5, 6})
+	testErrors(t, nil, err, 3)
+	if got.Weight != mst.Weight {
+		t.Error("steiner tree over every vertex should weigh the same as the spanning tree", got.Weight, mst.Weight)
+	}
+
+	for _, n := range []int{20, 100} {
+		g := Generate(n)
+		terminals := []int{2, n / 3, n / 2, n - 1}
+		tree, err := g.SteinerTree(terminals)
+		testErrors(t, nil, err, n)
+		reached := map[int]bool{}
+		for _, edge := range tree.Edges {
+			reached[edge.Src], reached[edge.Dest] = true, true
+		}
+		for _, terminal := range terminals {
+			if !reached[terminal] {
+				t.Error("terminal not in tree", n, terminal)
+			}
+		}
+		if len(tree.Edges) != len(reached)-1 {
+			t.Error("steiner tree has a cycle or is disconnected", n)
+		}
+	}
+
+	_, err = g.SteinerTree([]int{0, 5})
+	testErrors(t, nil, err, 4)
+	g.RemoveArc(5, 0)
+	_, err = g.SteinerTree([]int{0, 5})
+	testErrors(t, ErrNoPath, err, 5)
+	_, err = g.SteinerTree([]int{0, 9})
+	testErrors(t, ErrVertexNotFound, err, 6)
+}
+
+func TestMappedSpanningTree(t *testing.T) {
+	mg, _ := ImportStringMapped(testMappedGraphs[0].stringRepresentation)
+	prim, kruskal := mg.PrimMST(), mg.KruskalMST()
+	if !reflect.DeepEqual(prim, kruskal) {
+		t.Error("prim and kruskal differ", prim, kruskal)
+	}
+	if len(prim.Edges) != 5 {
+		t.Error("wrong amount of edges", prim)
+	}
+	tree, err := mg.SteinerTree([]string{"A", "F"})
+	testErrors(t, nil, err, 0)
+	if tree.Weight != 15 {
+		t.Error("wrong steiner tree", tree)
+	}
+	_, err = mg.SteinerTree([]string{"A", "Z"})
+	testErrors(t, ErrVertexNotFound, err, 1)
+}
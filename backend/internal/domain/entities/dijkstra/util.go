@@ -111,31 +111,37 @@ func ImportStringMapped(data string) (mg MappedGraph[string], err error) {
 	return
 }
 
+// Export writes the graph in the Import format, vertices and arcs are
+// ordered by index
 func (g Graph) Export() (string, error) {
 	var result = strings.Builder{}
 	for id, v := range g.vertexArcs {
 		result.WriteString(strconv.Itoa(id))
-		for key, val := range v {
-			result.WriteString(" " + strconv.Itoa(key) + "," + strconv.FormatUint(val, 10))
+		for _, key := range sortedKeys(v) {
+			result.WriteString(" " + strconv.Itoa(key) + "," + strconv.FormatUint(v[key], 10))
 		}
 		result.WriteRune('\n')
 	}
 	return result.String(), nil
 }
+
+// Export writes the graph in the ImportStringMapped format, vertices and arcs
+// are ordered by index
 func (mg MappedGraph[T]) Export() (string, error) {
 	var err error
 	var result = strings.Builder{}
-	for k, v := range mg.mapping {
+	names := mg.indexedVertices()
+	for _, k := range mg.orderedVertices() {
+		v := mg.mapping[k]
 		result.WriteString(fmt.Sprint(k))
 		if err = mg.graph.vertexValid(v); err != nil {
 			return "", err
 		}
-		for to, dist := range mg.graph.vertexArcs[v] {
-			name, err := mg.getInverseMap(to)
-			if err != nil {
-				return "", err
+		for _, to := range sortedKeys(mg.graph.vertexArcs[v]) {
+			if to >= len(names) {
+				return "", newErrMapNotFound(to)
 			}
-			result.WriteString(" " + fmt.Sprint(name) + "," + strconv.FormatUint(dist, 10))
+			result.WriteString(" " + fmt.Sprint(names[to]) + "," + strconv.FormatUint(mg.graph.vertexArcs[v][to], 10))
 		}
 		result.WriteRune('\n')
 	}
@@ -0,0 +1,52 @@
+package dijkstra
+
+// Widest calculates the path from src to dest whose smallest arc is the
+// largest, the Distance of the path is that smallest arc. It is the path of
+// the largest capacity when the arcs are weighted by their capacity.
+func (g Graph) Widest(src, dest int) (BestPath[int], error) {
+	if err := g.searchValid(src, dest); err != nil {
+		return BestPath[int]{}, err
+	}
+	widths := make([]uint64, len(g.vertexArcs))
+	previous := make([]int, len(g.vertexArcs))
+	visited := make([]bool, len(g.vertexArcs))
+	for i := range previous {
+		previous[i] = -1
+	}
+	widths[src] = Infinity
+	visiting := g.getList(listLongPQ)
+	visiting.PushOrdered(currentDistance{src, Infinity})
+	for visiting.Len() > 0 {
+		current := visiting.PopOrdered()
+		if visited[current.id] {
+			continue
+		}
+		visited[current.id] = true
+		if current.id == dest {
+			return BestPath[int]{widths[dest], buildPath(previous, src, dest)}, nil
+		}
+		for to, dist := range g.vertexArcs[current.id] {
+			width := min(widths[current.id], dist)
+			if !visited[to] && width > widths[to] {
+				widths[to] = width
+				previous[to] = current.id
+				visiting.PushOrdered(currentDistance{to, width})
+			}
+		}
+	}
+	return BestPath[int]{}, newErrNoPath(src, dest)
+}
+
+// Widest calculates the path from src to dest whose smallest arc is the
+// largest
+func (mg MappedGraph[T]) Widest(src, dest T) (BestPath[T], error) {
+	srcID, destID, err := mg.getMap2(src, dest)
+	if err != nil {
+		return BestPath[T]{}, err
+	}
+	best, err := mg.graph.Widest(srcID, destID)
+	if err != nil {
+		return BestPath[T]{}, mg.toMappedErr(err)
+	}
+	return mg.toMappedBestPath(best)
+}
@@ -0,0 +1,92 @@
+package dijkstra
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// reachableWith tells if dest can be reached from src using only the arcs of
+// at least width
+func reachableWith(g Graph, src, dest int, width uint64) bool {
+	seen := make([]bool, len(g.vertexArcs))
+	queue := []int{src}
+	seen[src] = true
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if v == dest {
+			return true
+		}
+		for to, dist := range g.vertexArcs[v] {
+			if dist >= width && !seen[to] {
+				seen[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+	return false
+}
+
+func TestWidest(t *testing.T) {
+	g := Graph{[]map[int]uint64{
+		0: {1: 10, 2: 4},
+		1: {3: 3},
+		2: {3: 5},
+		3: {},
+	}}
+	best, err := g.Widest(0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best.Distance != 4 || !slices.Equal(best.Path, []int{0, 2, 3}) {
+		t.Fatal("wrong widest path", best)
+	}
+	if _, err := g.Widest(3, 0); !errors.Is(err, ErrNoPath) {
+		t.Fatal("expected no path", err)
+	}
+}
+
+func TestWidestRandom(t *testing.T) {
+	for gi, g := range []Graph{Generate(50), Generate(200), constrainedTestGraph()} {
+		seeded := rand.New(rand.NewSource(int64(gi)))
+		for i := range 50 {
+			src, dest := seeded.Intn(len(g.vertexArcs)), seeded.Intn(len(g.vertexArcs))
+			best, err := g.Widest(src, dest)
+			if err != nil {
+				if src != dest && reachableWith(g, src, dest, 1) {
+					t.Fatal("widest found no path", gi, i, src, dest, err)
+				}
+				continue
+			}
+			width := Infinity
+			for j := 0; j < len(best.Path)-1; j++ {
+				width = min(width, g.vertexArcs[best.Path[j]][best.Path[j+1]])
+			}
+			if width != best.Distance {
+				t.Fatal("distance is not the smallest arc", gi, i, best, width)
+			}
+			if !reachableWith(g, src, dest, width) || reachableWith(g, src, dest, width+1) {
+				t.Fatal("path is not the widest", gi, i, src, dest, best)
+			}
+		}
+	}
+}
+
+func TestMappedWidest(t *testing.T) {
+	mg := NewMappedGraph[string]()
+	mg.AddEmptyVertex("a")
+	mg.AddEmptyVertex("b")
+	mg.AddEmptyVertex("c")
+	mg.AddArc("a", "b", 2)
+	mg.AddArc("b", "c", 7)
+	mg.AddArc("a", "c", 1)
+	best, err := mg.Widest("a", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best.Distance != 2 || !slices.Equal(best.Path, []string{"a", "b", "c"}) {
+		t.Fatal("wrong widest path", best)
+	}
+}
@@ -1,10 +1,15 @@
 package entities
 
 import (
+	"cmp"
+	"maps"
 	"math"
 	"math/rand"
+	"slices"
 	"sync"
 	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities/dijkstra"
 )
 
 const (
@@ -16,15 +21,55 @@ type Environment struct {
 	mu      sync.Mutex
 	Devices Devices
 	Chart   Chart
+	// Topology has the shared link graph of every routing type, updated by the
+	// routing loop and queried by the API
+	Topology map[string]*dijkstra.ConcurrentGraph[string]
+	// Convergence follows how long the routing tables take to match the
+	// links between devices
+	Convergence *Convergence
+	// Policies choose how the user messages are routed
+	Policies *RoutingPolicies
+	// Multipath spreads the user messages over the paths to their destination
+	Multipath *Multipath
+	// Forwarding chooses if the relays follow the path of the sender or their
+	// own routing tables
+	Forwarding *Forwarding
+	// Propagation tells which devices hear each other and how well
+	Propagation PropagationConfig
+	// Medium makes the concurrent transmissions interfere
+	Medium *Medium
+	// MAC has the devices contend for the medium before they send
+	MAC *MAC
 }
 
 func NewEnvironment() Environment {
 	return Environment{
-		Devices: make(Devices),
-		Chart:   make(Chart),
+		Devices:     make(Devices),
+		Chart:       make(Chart),
+		Topology:    make(map[string]*dijkstra.ConcurrentGraph[string]),
+		Convergence: NewConvergence(),
+		Policies:    NewRoutingPolicies(),
+		Multipath:   NewMultipath(),
+		Forwarding:  NewForwarding(),
+		Propagation: DefaultPropagationConfig(),
+		Medium:      NewMedium(),
+		MAC:         NewMAC(),
 	}
 }
 
+// GetTopology returns the shared link graph named name, it is created empty
+// the first time it is asked for
+func (e *Environment) GetTopology(name string) *dijkstra.ConcurrentGraph[string] {
+	e.mu.Lock()
+	topology, exists := e.Topology[name]
+	if !exists {
+		topology = dijkstra.NewConcurrentGraph[string]()
+		e.Topology[name] = topology
+	}
+	e.mu.Unlock()
+	return topology
+}
+
 func (e *Environment) SetDeviceInChart(deviceLabel string, coverageArea CoverageArea) {
 	e.mu.Lock()
 	e.Chart[deviceLabel] = &coverageArea
@@ -38,16 +83,24 @@ func (e *Environment) GetDeviceInChart(deviceLabel string) *CoverageArea {
 	return chart
 }
 
+// GetChart returns a copy of the chart, the positions in it do not change
+// when the devices move
 func (e *Environment) GetChart() Chart {
 	e.mu.Lock()
-	chart := e.Chart
+	chart := make(Chart, len(e.Chart))
+	for label, coverageArea := range e.Chart {
+		position := *coverageArea
+		chart[label] = &position
+	}
 	e.mu.Unlock()
 	return chart
 }
 
+// GetDevices returns a copy of the devices map, safe to range over while
+// devices are added and removed
 func (e *Environment) GetDevices() Devices {
 	e.mu.Lock()
-	devices := e.Devices
+	devices := maps.Clone(e.Devices)
 	e.mu.Unlock()
 	return devices
 }
@@ -83,6 +136,196 @@ func (e *Environment) GetDistanceTo(fromX, fromY, toX, toY int) float64 {
 	return math.Sqrt(math.Pow(float64(fromX-toX), 2) + math.Pow(float64(fromY-toY), 2))
 }
 
+// DeliveryRatio returns the probability that a frame sent by source reaches
+// target, none of them when target can not hear source
+func (e *Environment) DeliveryRatio(source, target string) float64 {
+	link, exists := e.RadioLink(source, target)
+	if !exists || !link.InRange {
+		return 0
+	}
+
+	return 1 - link.ErrorRate
+}
+
+func (e *Environment) GetPropagation() PropagationConfig {
+	e.mu.Lock()
+	config := e.Propagation
+	e.mu.Unlock()
+	return config
+}
+
+// SetPropagation changes the propagation model and the coverage radius of
+// every device in the chart with it
+func (e *Environment) SetPropagation(config PropagationConfig) error {
+	if _, err := config.PropagationModel(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.Propagation = config
+	for label, coverageArea := range e.Chart {
+		if device, exists := e.Devices[label]; exists {
+			coverageArea.R = e.coverageRadius(device)
+		}
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// CoverageRadius returns how far device reaches in the chart. Out of the disc
+// model it is where its signal drops to its own sensitivity with no
+// shadowing, the receivers with other sensitivities hear it a bit farther or
+// closer.
+func (e *Environment) CoverageRadius(device *Device) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.coverageRadius(device)
+}
+
+func (e *Environment) coverageRadius(device *Device) float64 {
+	model, err := e.Propagation.PropagationModel()
+	if err != nil || model == nil {
+		return float64(device.Power)
+	}
+
+	txPower, sensitivity := device.GetRadio()
+	return e.Propagation.Range(model, txPower, sensitivity)
+}
+
+// RadioLink returns what target gets from source, false when one of them is
+// not in the chart
+func (e *Environment) RadioLink(source, target string) (RadioLink, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.radioLink(source, target)
+}
+
+// RadioLinks returns the links of every device to the devices that hear it,
+// sorted by source and target
+func (e *Environment) RadioLinks() []RadioLink {
+	e.mu.Lock()
+	links := make([]RadioLink, 0)
+	for source := range e.Chart {
+		for target := range e.Chart {
+			if source == target {
+				continue
+			}
+			if link, exists := e.radioLink(source, target); exists && link.InRange {
+				links = append(links, link)
+			}
+		}
+	}
+	e.mu.Unlock()
+
+	slices.SortFunc(links, func(a, b RadioLink) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+		)
+	})
+	return links
+}
+
+// DirectionalLinks returns the pairs of devices where at least one hears the
+// other, sorted by source and target
+func (e *Environment) DirectionalLinks() []DirectionalLink {
+	e.mu.Lock()
+	links := make([]DirectionalLink, 0)
+	for source := range e.Chart {
+		for target := range e.Chart {
+			if source >= target {
+				continue
+			}
+
+			forward, exists := e.radioLink(source, target)
+			if !exists {
+				continue
+			}
+			reverse, _ := e.radioLink(target, source)
+			if !forward.InRange && !reverse.InRange {
+				continue
+			}
+			if !forward.InRange {
+				forward, reverse = reverse, forward
+			}
+
+			links = append(links, DirectionalLink{
+				Source:        forward.Source,
+				Target:        forward.Target,
+				Forward:       forward,
+				Reverse:       reverse,
+				Bidirectional: reverse.InRange,
+			})
+		}
+	}
+	e.mu.Unlock()
+
+	slices.SortFunc(links, func(a, b DirectionalLink) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+		)
+	})
+	return links
+}
+
+// IsBidirectional tells if source and target hear each other
+func (e *Environment) IsBidirectional(source, target string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	forward, exists := e.radioLink(source, target)
+	if !exists || !forward.InRange {
+		return false
+	}
+	reverse, _ := e.radioLink(target, source)
+	return reverse.InRange
+}
+
+// radioLink needs e.mu held. The disc model links the devices in the
+// coverage area of source and loses more frames the farther target is, up to
+// half of them at the edge. The other models link the devices that get the
+// signal of source over their sensitivity and lose frames by the margin.
+func (e *Environment) radioLink(source, target string) (RadioLink, bool) {
+	sourcePosition, sourceExists := e.Chart[source]
+	targetPosition, targetExists := e.Chart[target]
+	sourceDevice, sourceIsDevice := e.Devices[source]
+	targetDevice, targetIsDevice := e.Devices[target]
+	if !sourceExists || !targetExists || !sourceIsDevice || !targetIsDevice {
+		return RadioLink{}, false
+	}
+
+	link := RadioLink{
+		Source:   source,
+		Target:   target,
+		Distance: e.GetDistanceTo(sourcePosition.X, sourcePosition.Y, targetPosition.X, targetPosition.Y),
+	}
+
+	txPower, _ := sourceDevice.GetRadio()
+	_, sensitivity := targetDevice.GetRadio()
+	meters := link.Distance * e.Propagation.MetersPerUnit
+
+	model, err := e.Propagation.PropagationModel()
+	if err != nil || model == nil {
+		link.RSSI = txPower - FreeSpace{FrequencyMHz: e.Propagation.FrequencyMHz}.PathLoss(meters, 0)
+		link.InRange = sourcePosition.R > 0 && e.CheckIfIsInTheCoverageArea(link.Distance, sourcePosition.R)
+		link.ErrorRate = 1
+		if link.InRange {
+			link.ErrorRate = math.Pow(link.Distance/sourcePosition.R, 2) / 2
+		}
+		return link, true
+	}
+
+	sample := linkSample(source, *sourcePosition, target, *targetPosition)
+	link.RSSI = txPower - model.PathLoss(meters, sample)
+	link.InRange = link.RSSI >= sensitivity
+	link.ErrorRate = 1
+	if link.InRange {
+		link.ErrorRate = ErrorRateFromMargin(link.RSSI - sensitivity)
+	}
+	return link, true
+}
+
 func (e *Environment) CheckIfIsInTheCoverageArea(distance, r float64) bool {
 	return distance <= r
 }
@@ -116,8 +359,7 @@ func (e *Environment) RemoveDevice(deviceLabel string) {
 
 func (e *Environment) ScanDeviceNearby(deviceLabel string) []*Device {
 	e.mu.Lock()
-	sourcePosititon, exists := e.Chart[deviceLabel]
-	if !exists {
+	if _, exists := e.Chart[deviceLabel]; !exists {
 		e.mu.Unlock()
 		return nil
 	}
@@ -129,14 +371,7 @@ func (e *Environment) ScanDeviceNearby(deviceLabel string) []*Device {
 			continue
 		}
 
-		targetPosition, exists := e.Chart[label]
-		if !exists {
-			continue
-		}
-
-		distance := e.GetDistanceTo(sourcePosititon.X, sourcePosititon.Y, targetPosition.X, targetPosition.Y)
-
-		if e.CheckIfIsInTheCoverageArea(distance, sourcePosititon.R) {
+		if link, exists := e.radioLink(deviceLabel, label); exists && link.InRange {
 			devicesNearby = append(devicesNearby, device)
 		}
 	}
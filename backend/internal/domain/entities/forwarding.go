@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// ForwardingSource has the sender put the whole path in the message, the
+	// relays follow it without looking at their own tables
+	ForwardingSource = "source"
+	// ForwardingHopByHop has every relay look up the next hop to the target
+	// in its own routing table
+	ForwardingHopByHop = "hop-by-hop"
+)
+
+// DefaultHopLimit is the amount of hops a hop-by-hop message can take before
+// it is dropped, so loops between inconsistent tables end
+const DefaultHopLimit = 16
+
+func ValidateForwardingMode(mode string) error {
+	switch mode {
+	case ForwardingSource, ForwardingHopByHop:
+		return nil
+	default:
+		return fmt.Errorf("unknown forwarding mode: %s", mode)
+	}
+}
+
+// ForwardingStats counts what happened to the user messages of a forwarding
+// mode
+type ForwardingStats struct {
+	Sent      uint64
+	Delivered uint64
+	// NoRoute are the messages dropped by a device with no route to the
+	// target
+	NoRoute uint64
+	// Expired are the messages dropped when they ran out of hops
+	Expired uint64
+	// Loops are the times a message came back to a device it went through
+	Loops uint64
+}
+
+// Forwarding keeps the default forwarding mode of an environment and counts
+// the user messages of every mode
+type Forwarding struct {
+	mu    sync.Mutex
+	mode  string
+	stats map[string]*ForwardingStats
+}
+
+func NewForwarding() *Forwarding {
+	return &Forwarding{
+		mode: ForwardingSource,
+		stats: map[string]*ForwardingStats{
+			ForwardingSource:   {},
+			ForwardingHopByHop: {},
+		},
+	}
+}
+
+func (f *Forwarding) GetMode() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.mode
+}
+
+func (f *Forwarding) SetMode(mode string) error {
+	if err := ValidateForwardingMode(mode); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.mode = mode
+	f.mu.Unlock()
+	return nil
+}
+
+// Resolve returns the mode of a message that asked for requested, the default
+// mode when it asked for none
+func (f *Forwarding) Resolve(requested string) (string, error) {
+	if requested == "" {
+		return f.GetMode(), nil
+	}
+	if err := ValidateForwardingMode(requested); err != nil {
+		return "", err
+	}
+	return requested, nil
+}
+
+// Count updates the counters of mode
+func (f *Forwarding) Count(mode string, update func(stats *ForwardingStats)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if stats, exists := f.stats[mode]; exists {
+		update(stats)
+	}
+}
+
+// GetStats returns the counters of every mode
+func (f *Forwarding) GetStats() map[string]ForwardingStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stats := make(map[string]ForwardingStats, len(f.stats))
+	for mode, modeStats := range f.stats {
+		stats[mode] = *modeStats
+	}
+	return stats
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestForwardingResolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		requested string
+		want      string
+		valid     bool
+	}{
+		{"no mode asked takes the default", ForwardingSource, "", ForwardingSource, true},
+		{"no mode asked takes the changed default", ForwardingHopByHop, "", ForwardingHopByHop, true},
+		{"the asked mode beats the default", ForwardingSource, ForwardingHopByHop, ForwardingHopByHop, true},
+		{"an unknown mode is rejected", ForwardingSource, "flooding", "", false},
+	}
+	for _, test := range tests {
+		forwarding := NewForwarding()
+		if err := forwarding.SetMode(test.mode); err != nil {
+			t.Fatal(err)
+		}
+
+		mode, err := forwarding.Resolve(test.requested)
+		if (err == nil) != test.valid || mode != test.want {
+			t.Errorf("%s: resolved %q with %v, want %q (valid %v)", test.name, mode, err, test.want, test.valid)
+		}
+	}
+}
+
+func TestForwardingSetMode(t *testing.T) {
+	forwarding := NewForwarding()
+	if err := forwarding.SetMode("flooding"); err == nil {
+		t.Error("an unknown mode should be rejected")
+	}
+	if mode := forwarding.GetMode(); mode != ForwardingSource {
+		t.Error("a rejected mode should keep the default, got", mode)
+	}
+}
+
+func TestForwardingCount(t *testing.T) {
+	forwarding := NewForwarding()
+	forwarding.Count(ForwardingHopByHop, func(stats *ForwardingStats) { stats.Sent++ })
+	forwarding.Count(ForwardingHopByHop, func(stats *ForwardingStats) { stats.Loops++ })
+	forwarding.Count("flooding", func(stats *ForwardingStats) { stats.Sent++ })
+
+	stats := forwarding.GetStats()
+	if len(stats) != 2 {
+		t.Fatal("only the known modes should be counted, got", stats)
+	}
+	if stats[ForwardingHopByHop] != (ForwardingStats{Sent: 1, Loops: 1}) {
+		t.Error("the hop-by-hop counters are wrong", stats[ForwardingHopByHop])
+	}
+	if stats[ForwardingSource] != (ForwardingStats{}) {
+		t.Error("the source counters should be untouched", stats[ForwardingSource])
+	}
+}
+
+func TestRequestRelay(t *testing.T) {
+	request := NewRequest("user-message", "A", "B", nil, "hello")
+	request.Header.ContentType = "text"
+	request.Header.Forwarding = ForwardingHopByHop
+	request.Header.Target = "D"
+	request.Header.RoutingType = "distance"
+	request.Header.HopLimit = 3
+	request.Header.Trace = []string{"A"}
+
+	hop := request.RelayHop("B", "C")
+	if hop.Header.Sender != "B" || hop.Header.Destination != "C" || hop.Body != "hello" {
+		t.Error("the relayed message should go from the relay to the next hop", hop.Header)
+	}
+	if hop.Header.HopLimit != 2 {
+		t.Error("a hop should be used up, got", hop.Header.HopLimit)
+	}
+	if !slices.Equal(hop.Header.Trace, []string{"A", "B"}) {
+		t.Error("the relay should be added to the trace, got", hop.Header.Trace)
+	}
+	if hop.Header.Target != "D" || hop.Header.RoutingType != "distance" || hop.Header.ContentType != "text" {
+		t.Error("the relayed message should keep the header", hop.Header)
+	}
+
+	next := hop.RelayHop("C", "D")
+	if next.Header.HopLimit != 1 || !slices.Equal(next.Header.Trace, []string{"A", "B", "C"}) {
+		t.Error("every relay should use up a hop and extend the trace", next.Header)
+	}
+	if !slices.Equal(hop.Header.Trace, []string{"A", "B"}) || !slices.Equal(request.Header.Trace, []string{"A"}) {
+		t.Error("relaying should not change the trace of the relayed messages")
+	}
+
+	path := []Route{{Source: "C", Target: "D"}}
+	source := request.Relay("B", "C", path)
+	if source.Header.HopLimit != 3 || !slices.Equal(source.Header.Path, path) {
+		t.Error("a source routed relay should keep the hops and follow the path", source.Header)
+	}
+}
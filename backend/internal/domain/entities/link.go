@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+const (
+	// LinkOutbound is a neighbour this device reaches that never answered
+	LinkOutbound = "outbound"
+	// LinkInbound is a neighbour this device hears but can not answer
+	LinkInbound = "inbound"
+)
+
+// DirectionalLink is the radio link between two devices in both directions.
+// When only one of them reaches the other, Source is the one that does.
+type DirectionalLink struct {
+	Source        string
+	Target        string
+	Forward       RadioLink
+	Reverse       RadioLink
+	Bidirectional bool
+}
+
+// OneWayLink is a neighbour the handshake could not confirm, the device does
+// not route through it
+type OneWayLink struct {
+	Neighbour string
+	Direction string
+	Quality   LinkQuality
+	Since     time.Time
+}
+
+func sortOneWayLinks(links []OneWayLink) {
+	slices.SortFunc(links, func(a, b OneWayLink) int {
+		return cmp.Compare(a.Neighbour, b.Neighbour)
+	})
+}
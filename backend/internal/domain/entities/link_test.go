@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDirectionalLinks(t *testing.T) {
+	// A sends at 10 dBm, it hears B but B can not hear it back
+	environment := mediumTestEnvironment(t, map[string]int{"A": 0, "B": 20, "C": 2, "D": 80})
+	environment.GetDeviceByLabel("A").TxPower = DefaultTxPower - 10
+
+	links := environment.DirectionalLinks()
+	if len(links) != 3 {
+		t.Fatal("the far device should have no link, got", links)
+	}
+
+	oneWay, bidirectional := links[1], links[0]
+	if oneWay.Source != "B" || oneWay.Target != "A" || oneWay.Bidirectional {
+		t.Error("the one-way link should go from the side that reaches the other", oneWay)
+	}
+	if !oneWay.Forward.InRange || oneWay.Reverse.InRange || oneWay.Forward.Source != "B" {
+		t.Error("the forward direction should be the one in range", oneWay.Forward, oneWay.Reverse)
+	}
+	if bidirectional.Source != "A" || bidirectional.Target != "C" || !bidirectional.Bidirectional {
+		t.Error("the near devices should hear each other", bidirectional)
+	}
+
+	if environment.IsBidirectional("A", "B") || environment.IsBidirectional("B", "A") {
+		t.Error("a one-way pair should not be bidirectional either way")
+	}
+	if !environment.IsBidirectional("A", "C") || !environment.IsBidirectional("C", "A") {
+		t.Error("the near devices should be bidirectional both ways")
+	}
+}
+
+func TestOneWayLinkCleared(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{Label: "A", DevicesWithConn: make(map[string]Connection)}
+
+	device.SetOneWayLink(OneWayLink{Neighbour: "C", Direction: LinkOutbound, Since: now})
+	device.SetOneWayLink(OneWayLink{Neighbour: "B", Direction: LinkInbound, Since: now})
+	if links := device.GetOneWayLinks(); len(links) != 2 || links[0].Neighbour != "B" {
+		t.Fatal("the one-way links should be kept sorted by neighbour, got", links)
+	}
+
+	device.SetDeviceWithConn("B", Connection{})
+	if links := device.GetOneWayLinks(); len(links) != 1 || links[0].Neighbour != "C" {
+		t.Error("a confirmed connection should clear its one-way link, got", links)
+	}
+
+	device.SetOneWayLink(OneWayLink{Neighbour: "B", Direction: LinkInbound, Since: now})
+	if links := device.GetOneWayLinks(); len(links) != 1 {
+		t.Error("a connected neighbour should not be kept as one-way, got", links)
+	}
+
+	device.ResetDeviceConn()
+	if links := device.GetOneWayLinks(); len(links) != 0 {
+		t.Error("a new scan should forget the one-way links, got", links)
+	}
+}
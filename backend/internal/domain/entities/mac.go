@@ -0,0 +1,353 @@
+package entities
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Sizes of the control frames of the MAC, in bytes
+const (
+	RTSSize = 20
+	CTSSize = 14
+	ACKSize = 14
+)
+
+// MACConfig tells how the devices contend for the shared medium, carrier sense
+// with random backoff, RTS/CTS for the large frames and ACKs with retries
+type MACConfig struct {
+	// Enabled needs the shared medium, when it is off the devices send as soon
+	// as they have a frame
+	Enabled bool
+	Slot    time.Duration
+	SIFS    time.Duration
+	DIFS    time.Duration
+	// CWMin and CWMax bound the contention window, the backoff is a random
+	// amount of slots in it and the window doubles on every retry
+	CWMin int
+	CWMax int
+	// RetryLimit is how many times a frame is sent again before it is dropped
+	RetryLimit int
+	// RTSThreshold is the size from which frames are sent after an RTS/CTS
+	// exchange, in bytes
+	RTSThreshold int
+}
+
+func DefaultMACConfig() MACConfig {
+	return MACConfig{
+		Enabled:      false,
+		Slot:         20 * time.Microsecond,
+		SIFS:         10 * time.Microsecond,
+		DIFS:         50 * time.Microsecond,
+		CWMin:        15,
+		CWMax:        1023,
+		RetryLimit:   7,
+		RTSThreshold: 500,
+	}
+}
+
+func (c MACConfig) Validate() error {
+	if c.Slot <= 0 || c.SIFS <= 0 || c.DIFS <= 0 {
+		return fmt.Errorf("slot, sifs and difs must be positive")
+	}
+	if c.CWMin < 1 || c.CWMax < c.CWMin {
+		return fmt.Errorf("contention window must be at least 1 and cw max at least cw min")
+	}
+	if c.RetryLimit < 0 {
+		return fmt.Errorf("retry limit can not be negative")
+	}
+	if c.RTSThreshold < 0 {
+		return fmt.Errorf("rts threshold can not be negative")
+	}
+	return nil
+}
+
+// NextWindow returns the contention window after a failed attempt
+func (c MACConfig) NextWindow(window int) int {
+	return min(2*window+1, c.CWMax)
+}
+
+// MACStats counts how the frames of a device went through the MAC
+type MACStats struct {
+	Frames    uint64
+	Delivered uint64
+	// Dropped are the frames that ran out of retries or that could not reach
+	// their target
+	Dropped uint64
+	// OutOfRange are the frames dropped at once because the target, or the
+	// source for the CTS and the ACK, could not hear them
+	OutOfRange uint64
+	// WeakSignal are the frames dropped at once because they were heard too
+	// weak for the data rate, retrying at the same rate would not help
+	WeakSignal uint64
+	Retries    uint64
+	// Collisions are the attempts lost to concurrent transmissions
+	Collisions  uint64
+	RTSFailures uint64
+	ACKTimeouts uint64
+	// Deferrals are the times the device found the medium busy
+	Deferrals uint64
+	// BackoffSlots are the idle slots the device waited
+	BackoffSlots uint64
+}
+
+// MAC keeps the MAC config of an environment, the network allocation vector of
+// every device, its queue of frames and its counters
+type MAC struct {
+	mu     sync.Mutex
+	config MACConfig
+	// nav is until when a device heard an RTS/CTS exchange reserve the medium
+	nav   map[string]time.Time
+	stats map[string]*MACStats
+	// queues has the frames every device waits to send, a device with a
+	// queue has a sender going through it
+	queues map[string][]func()
+}
+
+func NewMAC() *MAC {
+	return &MAC{
+		config: DefaultMACConfig(),
+		nav:    make(map[string]time.Time),
+		stats:  make(map[string]*MACStats),
+		queues: make(map[string][]func()),
+	}
+}
+
+func (m *MAC) GetConfig() MACConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config
+}
+
+func (m *MAC) SetConfig(config MACConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.config = config
+	m.mu.Unlock()
+	return nil
+}
+
+// Reserve keeps label off the medium until until, unless it already is for
+// longer
+func (m *MAC) Reserve(label string, until time.Time) {
+	m.mu.Lock()
+	if until.After(m.nav[label]) {
+		m.nav[label] = until
+	}
+	m.mu.Unlock()
+}
+
+// Reserved returns until when label is kept off the medium
+func (m *MAC) Reserved(label string) time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nav[label]
+}
+
+// Enqueue adds send to the queue of label. The frames of a device are sent
+// one at a time in the order they were queued, by a sender started with the
+// first frame and gone once the queue is empty.
+func (m *MAC) Enqueue(label string, send func()) {
+	m.mu.Lock()
+	queue, sending := m.queues[label]
+	m.queues[label] = append(queue, send)
+	m.mu.Unlock()
+
+	if !sending {
+		go m.sendQueue(label)
+	}
+}
+
+// sendQueue sends the frames of label until its queue is empty
+func (m *MAC) sendQueue(label string) {
+	for {
+		m.mu.Lock()
+		queue := m.queues[label]
+		if len(queue) == 0 {
+			delete(m.queues, label)
+			m.mu.Unlock()
+			return
+		}
+		send := queue[0]
+		m.queues[label] = queue[1:]
+		m.mu.Unlock()
+
+		send()
+	}
+}
+
+// Count updates the counters of label
+func (m *MAC) Count(label string, update func(stats *MACStats)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, exists := m.stats[label]
+	if !exists {
+		stats = &MACStats{}
+		m.stats[label] = stats
+	}
+	update(stats)
+}
+
+// GetStats returns the counters of every device
+func (m *MAC) GetStats() map[string]MACStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make(map[string]MACStats, len(m.stats))
+	for label, deviceStats := range m.stats {
+		stats[label] = *deviceStats
+	}
+	return stats
+}
+
+// ResetStats clears the counters
+func (m *MAC) ResetStats() {
+	m.mu.Lock()
+	m.stats = make(map[string]*MACStats)
+	m.mu.Unlock()
+}
+
+// SenseCarrier tells if label finds the medium busy at now, because it hears
+// a frame on the air or an RTS/CTS exchange reserved it, and until when
+func (e *Environment) SenseCarrier(label string, now time.Time) (bool, time.Time) {
+	until := e.MAC.Reserved(label)
+	busy := until.After(now)
+
+	for _, transmission := range e.Medium.OnAir(now) {
+		if transmission.Source != label {
+			link, exists := e.RadioLink(transmission.Source, label)
+			if !exists || !link.InRange {
+				continue
+			}
+		}
+
+		busy = true
+		if transmission.End.After(until) {
+			until = transmission.End
+		}
+	}
+
+	return busy, until
+}
+
+// MACFrame is a frame a device hands to the MAC
+type MACFrame struct {
+	Source string
+	Target string
+	Topic  string
+	Size   int
+}
+
+// MACAir is how the MAC waits and sends on the shared medium
+type MACAir interface {
+	// Backoff waits for the medium to be idle and then for a random amount of
+	// idle slots in the contention window
+	Backoff(label string, window int)
+	// Wait waits for d
+	Wait(d time.Duration)
+	// Send puts a frame on the air and returns how the target got it once it
+	// is off the air
+	Send(source, target, topic string, size int) Reception
+	// Reserve keeps the devices that hear source off the medium for d, except
+	// target which takes part in the exchange
+	Reserve(source, target string, d time.Duration)
+}
+
+// Send runs the CSMA/CA exchange of frame on air, with the medium config to
+// time the exchange. The sender backs off, reserves the medium with RTS/CTS
+// when the frame is large and sends it again until the target acknowledges
+// it or the retries run out. deliver is called once, the first time the
+// target decodes the frame, so a frame received again because its ACK got
+// lost is kept once. Only the attempts lost to concurrent transmissions are
+// retried, the frames that can not be heard are dropped at once. It returns
+// if the frame was acknowledged.
+func (m *MAC) Send(frame MACFrame, medium MediumConfig, air MACAir, deliver func()) bool {
+	config := m.GetConfig()
+	source, target := frame.Source, frame.Target
+
+	m.Count(source, func(stats *MACStats) { stats.Frames++ })
+
+	// failed counts a lost attempt and tells if it is worth retrying
+	failed := func(reception Reception, counter func(stats *MACStats)) bool {
+		m.Count(source, func(stats *MACStats) {
+			counter(stats)
+			switch reception.Status {
+			case ReceptionCollision, ReceptionHalfDuplex:
+				stats.Collisions++
+			case ReceptionOutOfRange:
+				stats.OutOfRange++
+				stats.Dropped++
+			case ReceptionWeakSignal:
+				stats.WeakSignal++
+				stats.Dropped++
+			}
+		})
+		return reception.Status != ReceptionOutOfRange && reception.Status != ReceptionWeakSignal
+	}
+	rtsFailed := func(stats *MACStats) { stats.RTSFailures++ }
+	ackTimedOut := func(stats *MACStats) { stats.ACKTimeouts++ }
+
+	delivered := false
+	window := config.CWMin
+	for attempt := 0; attempt <= config.RetryLimit; attempt++ {
+		if attempt > 0 {
+			m.Count(source, func(stats *MACStats) { stats.Retries++ })
+			window = config.NextWindow(window)
+		}
+
+		air.Backoff(source, window)
+
+		if frame.Size >= config.RTSThreshold {
+			// the devices that hear the RTS or the CTS stay off the medium
+			// until the ACK
+			exchange := 3*config.SIFS + medium.Duration(CTSSize) + medium.Duration(frame.Size) + medium.Duration(ACKSize)
+			if rts := air.Send(source, target, "rts", RTSSize); rts.Status != ReceptionOK {
+				if !failed(rts, rtsFailed) {
+					return false
+				}
+				continue
+			}
+			air.Reserve(source, target, exchange)
+
+			air.Wait(config.SIFS)
+			if cts := air.Send(target, source, "cts", CTSSize); cts.Status != ReceptionOK {
+				if !failed(cts, rtsFailed) {
+					return false
+				}
+				continue
+			}
+			air.Reserve(target, source, exchange-config.SIFS-medium.Duration(CTSSize))
+
+			air.Wait(config.SIFS)
+		}
+
+		data := air.Send(source, target, frame.Topic, frame.Size)
+		if data.Status != ReceptionOK {
+			if !failed(data, ackTimedOut) {
+				return false
+			}
+			continue
+		}
+		if !delivered {
+			deliver()
+			delivered = true
+		}
+
+		air.Wait(config.SIFS)
+		ack := air.Send(target, source, "ack", ACKSize)
+		if ack.Status == ReceptionOK {
+			m.Count(source, func(stats *MACStats) { stats.Delivered++ })
+			return true
+		}
+		if !failed(ack, ackTimedOut) {
+			return false
+		}
+	}
+
+	m.Count(source, func(stats *MACStats) { stats.Dropped++ })
+	return false
+}
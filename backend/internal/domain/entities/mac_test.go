@@ -0,0 +1,289 @@
+package entities
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMACConfigNextWindow(t *testing.T) {
+	config := DefaultMACConfig()
+
+	tests := []struct {
+		window int
+		want   int
+	}{
+		{15, 31},
+		{31, 63},
+		{511, 1023},
+		{700, 1023},
+		{1023, 1023},
+	}
+	for _, test := range tests {
+		if got := config.NextWindow(test.window); got != test.want {
+			t.Errorf("window after %d is %d, want %d", test.window, got, test.want)
+		}
+	}
+}
+
+func TestMACConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(config *MACConfig)
+		valid  bool
+	}{
+		{"default config", func(config *MACConfig) {}, true},
+		{"no slot", func(config *MACConfig) { config.Slot = 0 }, false},
+		{"negative sifs", func(config *MACConfig) { config.SIFS = -time.Microsecond }, false},
+		{"no difs", func(config *MACConfig) { config.DIFS = 0 }, false},
+		{"empty window", func(config *MACConfig) { config.CWMin = 0 }, false},
+		{"cw max under cw min", func(config *MACConfig) { config.CWMax = config.CWMin - 1 }, false},
+		{"cw max equal to cw min", func(config *MACConfig) { config.CWMax = config.CWMin }, true},
+		{"no retries", func(config *MACConfig) { config.RetryLimit = 0 }, true},
+		{"negative retries", func(config *MACConfig) { config.RetryLimit = -1 }, false},
+		{"rts for every frame", func(config *MACConfig) { config.RTSThreshold = 0 }, true},
+		{"negative rts threshold", func(config *MACConfig) { config.RTSThreshold = -1 }, false},
+	}
+	for _, test := range tests {
+		config := DefaultMACConfig()
+		test.change(&config)
+		if err := config.Validate(); (err == nil) != test.valid {
+			t.Errorf("%s: validate returned %v, want valid %v", test.name, err, test.valid)
+		}
+	}
+}
+
+func TestMACReserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mac := NewMAC()
+
+	tests := []struct {
+		name  string
+		until time.Time
+		want  time.Time
+	}{
+		{"first reservation", now.Add(time.Millisecond), now.Add(time.Millisecond)},
+		{"longer reservation extends", now.Add(2 * time.Millisecond), now.Add(2 * time.Millisecond)},
+		{"shorter reservation is ignored", now.Add(time.Microsecond), now.Add(2 * time.Millisecond)},
+		{"past reservation is ignored", now.Add(-time.Second), now.Add(2 * time.Millisecond)},
+	}
+	for _, test := range tests {
+		mac.Reserve("A", test.until)
+		if got := mac.Reserved("A"); !got.Equal(test.want) {
+			t.Errorf("%s: reserved until %v, want %v", test.name, got, test.want)
+		}
+	}
+	if !mac.Reserved("B").IsZero() {
+		t.Error("a reservation should only apply to its device")
+	}
+}
+
+func TestSenseCarrier(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// 100 bytes take 992µs at 1 Mbps
+	frameEnd := now.Add(992 * time.Microsecond)
+
+	tests := []struct {
+		name  string
+		nav   time.Time
+		frame [2]string
+		label string
+		busy  bool
+		until time.Time
+	}{
+		{"idle medium", time.Time{}, [2]string{}, "A", false, time.Time{}},
+		{"reservation only", now.Add(time.Millisecond), [2]string{}, "A", true, now.Add(time.Millisecond)},
+		{"expired reservation", now.Add(-time.Millisecond), [2]string{}, "A", false, now.Add(-time.Millisecond)},
+		{"frame heard on the air", time.Time{}, [2]string{"B", "C"}, "A", true, frameEnd},
+		{"own frame on the air", time.Time{}, [2]string{"A", "B"}, "A", true, frameEnd},
+		{"frame too far to be heard", time.Time{}, [2]string{"D", "B"}, "A", false, time.Time{}},
+		{"reservation past the frame", now.Add(2 * time.Millisecond), [2]string{"B", "C"}, "A", true, now.Add(2 * time.Millisecond)},
+		{"frame past the reservation", now.Add(100 * time.Microsecond), [2]string{"B", "C"}, "A", true, frameEnd},
+	}
+	for _, test := range tests {
+		environment := mediumTestEnvironment(t, map[string]int{"A": 0, "B": 2, "C": 4, "D": 40})
+		if !test.nav.IsZero() {
+			environment.MAC.Reserve(test.label, test.nav)
+		}
+		if test.frame[0] != "" {
+			environment.Medium.Begin(test.frame[0], test.frame[1], "test", 100, now)
+		}
+
+		busy, until := environment.SenseCarrier(test.label, now.Add(time.Microsecond))
+		if busy != test.busy || !until.Equal(test.until) {
+			t.Errorf("%s: busy %v until %v, want %v until %v", test.name, busy, until, test.busy, test.until)
+		}
+	}
+}
+
+// scriptedAir answers the frames of the MAC with the receptions of its script,
+// taken in order for every topic, and keeps the frames sent
+type scriptedAir struct {
+	script map[string][]string
+	sent   []string
+}
+
+func (a *scriptedAir) Backoff(label string, window int) {}
+
+func (a *scriptedAir) Wait(d time.Duration) {}
+
+func (a *scriptedAir) Send(source, target, topic string, size int) Reception {
+	a.sent = append(a.sent, topic)
+
+	status := ReceptionOK
+	if statuses := a.script[topic]; len(statuses) > 0 {
+		status, a.script[topic] = statuses[0], statuses[1:]
+	}
+	return Reception{Status: status}
+}
+
+func (a *scriptedAir) Reserve(source, target string, d time.Duration) {}
+
+func TestMACSend(t *testing.T) {
+	frame := MACFrame{Source: "A", Target: "B", Topic: "data", Size: 100}
+
+	tests := []struct {
+		name         string
+		script       map[string][]string
+		acknowledged bool
+		deliveries   int
+		sent         []string
+		stats        MACStats
+	}{
+		{
+			name:         "acknowledged at once",
+			script:       map[string][]string{},
+			acknowledged: true,
+			deliveries:   1,
+			sent:         []string{"data", "ack"},
+			stats:        MACStats{Frames: 1, Delivered: 1},
+		},
+		{
+			name:         "lost ack delivers once and retries",
+			script:       map[string][]string{"ack": {ReceptionCollision}},
+			acknowledged: true,
+			deliveries:   1,
+			sent:         []string{"data", "ack", "data", "ack"},
+			stats:        MACStats{Frames: 1, Delivered: 1, Retries: 1, Collisions: 1, ACKTimeouts: 1},
+		},
+		{
+			name:         "half duplex counts as a collision",
+			script:       map[string][]string{"data": {ReceptionHalfDuplex}},
+			acknowledged: true,
+			deliveries:   1,
+			sent:         []string{"data", "data", "ack"},
+			stats:        MACStats{Frames: 1, Delivered: 1, Retries: 1, Collisions: 1, ACKTimeouts: 1},
+		},
+		{
+			name:         "out of range target drops the frame at once",
+			script:       map[string][]string{"data": {ReceptionOutOfRange}},
+			acknowledged: false,
+			deliveries:   0,
+			sent:         []string{"data"},
+			stats:        MACStats{Frames: 1, Dropped: 1, OutOfRange: 1, ACKTimeouts: 1},
+		},
+		{
+			name:         "weak signal drops the frame at once",
+			script:       map[string][]string{"data": {ReceptionWeakSignal}},
+			acknowledged: false,
+			deliveries:   0,
+			sent:         []string{"data"},
+			stats:        MACStats{Frames: 1, Dropped: 1, WeakSignal: 1, ACKTimeouts: 1},
+		},
+		{
+			name:         "out of range ack drops the delivered frame",
+			script:       map[string][]string{"ack": {ReceptionOutOfRange}},
+			acknowledged: false,
+			deliveries:   1,
+			sent:         []string{"data", "ack"},
+			stats:        MACStats{Frames: 1, Dropped: 1, OutOfRange: 1, ACKTimeouts: 1},
+		},
+		{
+			name: "retries run out",
+			script: map[string][]string{"data": {
+				ReceptionCollision, ReceptionCollision, ReceptionCollision,
+			}},
+			acknowledged: false,
+			deliveries:   0,
+			sent:         []string{"data", "data", "data"},
+			stats:        MACStats{Frames: 1, Dropped: 1, Retries: 2, Collisions: 3, ACKTimeouts: 3},
+		},
+	}
+	for _, test := range tests {
+		mac := NewMAC()
+		config := DefaultMACConfig()
+		config.RetryLimit = 2
+		if err := mac.SetConfig(config); err != nil {
+			t.Fatal(err)
+		}
+
+		air := &scriptedAir{script: test.script}
+		deliveries := 0
+		acknowledged := mac.Send(frame, DefaultMediumConfig(), air, func() { deliveries++ })
+
+		if acknowledged != test.acknowledged || deliveries != test.deliveries {
+			t.Errorf("%s: acknowledged %v with %d deliveries, want %v with %d", test.name, acknowledged, deliveries, test.acknowledged, test.deliveries)
+		}
+		if !slices.Equal(air.sent, test.sent) {
+			t.Errorf("%s: sent %v, want %v", test.name, air.sent, test.sent)
+		}
+		if stats := mac.GetStats()["A"]; stats != test.stats {
+			t.Errorf("%s: stats are %+v, want %+v", test.name, stats, test.stats)
+		}
+	}
+}
+
+func TestMACSendRTS(t *testing.T) {
+	mac := NewMAC()
+	frame := MACFrame{Source: "A", Target: "B", Topic: "data", Size: DefaultMACConfig().RTSThreshold}
+
+	air := &scriptedAir{script: map[string][]string{"cts": {ReceptionCollision}}}
+	if !mac.Send(frame, DefaultMediumConfig(), air, func() {}) {
+		t.Fatal("the frame should be acknowledged on the second attempt")
+	}
+
+	if want := []string{"rts", "cts", "rts", "cts", "data", "ack"}; !slices.Equal(air.sent, want) {
+		t.Errorf("sent %v, want %v", air.sent, want)
+	}
+	if stats := mac.GetStats()["A"]; stats.RTSFailures != 1 || stats.Collisions != 1 || stats.Retries != 1 {
+		t.Errorf("wrong stats after a lost cts: %+v", stats)
+	}
+}
+
+func TestMACEnqueue(t *testing.T) {
+	mac := NewMAC()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sent := make([]int, 0)
+	sending := 0
+
+	for i := range 20 {
+		wg.Add(1)
+		mac.Enqueue("A", func() {
+			defer wg.Done()
+
+			mu.Lock()
+			sending++
+			if sending > 1 {
+				t.Error("the frames of a device should be sent one at a time")
+			}
+			sent = append(sent, i)
+			mu.Unlock()
+
+			time.Sleep(100 * time.Microsecond)
+
+			mu.Lock()
+			sending--
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	for i := range sent {
+		if sent[i] != i {
+			t.Fatal("the frames of a device should be sent in order", sent)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// ReceptionOK is a frame the target decoded
+	ReceptionOK = "ok"
+	// ReceptionOutOfRange is a frame that reached the target under its
+	// sensitivity
+	ReceptionOutOfRange = "out-of-range"
+	// ReceptionCollision is a frame drowned by concurrent transmissions
+	ReceptionCollision = "collision"
+	// ReceptionHalfDuplex is a frame that came while the target was sending
+	ReceptionHalfDuplex = "half-duplex"
+	// ReceptionWeakSignal is a frame heard alone on the air too weak for the
+	// SINR threshold of its data rate
+	ReceptionWeakSignal = "weak-signal"
+)
+
+// maxCollisions is how many of the last collisions the medium keeps
+const maxCollisions = 50
+
+// DataRate is a rate of the radios and the SINR a frame needs to be decoded
+// at it, in dB
+type DataRate struct {
+	Mbps          float64
+	SINRThreshold float64
+}
+
+// DataRates are the rates the radios can send at, the faster the rate the
+// cleaner the signal has to be
+var DataRates = []DataRate{
+	{Mbps: 1, SINRThreshold: 4},
+	{Mbps: 2, SINRThreshold: 6},
+	{Mbps: 5.5, SINRThreshold: 8},
+	{Mbps: 11, SINRThreshold: 10},
+	{Mbps: 24, SINRThreshold: 16},
+	{Mbps: 54, SINRThreshold: 25},
+}
+
+// AdaptedRate returns the fastest data rate a signal snr dB over the noise can
+// be decoded at, false when it is too weak even for the slowest one
+func AdaptedRate(snr float64) (DataRate, bool) {
+	adapted, found := DataRate{}, false
+	for _, rate := range DataRates {
+		if rate.SINRThreshold <= snr && rate.Mbps > adapted.Mbps {
+			adapted, found = rate, true
+		}
+	}
+	return adapted, found
+}
+
+// LinkCapacity returns the traffic a link can carry in Mbps, the rate its
+// signal adapts to less the frames it loses. It only changes with the signal,
+// so it stays the same while the devices do not move.
+func LinkCapacity(quality LinkQuality, noiseFloor float64) float64 {
+	rate, found := AdaptedRate(quality.RSSI - noiseFloor)
+	if !found {
+		return 0
+	}
+	return rate.Mbps * (1 - min(max(quality.ErrorRate, 0), 1))
+}
+
+// MediumConfig tells if the devices share the medium and how they send on it
+type MediumConfig struct {
+	// Enabled makes the frames take time on the air and interfere, when it is
+	// off they get to the target at once
+	Enabled  bool
+	DataRate float64
+	// NoiseFloor is the power of the noise at every receiver, in dBm
+	NoiseFloor float64
+	// Preamble is the time every frame takes before its bytes
+	Preamble time.Duration
+}
+
+func (c MediumConfig) Validate() error {
+	if _, err := c.Rate(); err != nil {
+		return err
+	}
+	if c.Preamble < 0 {
+		return fmt.Errorf("preamble can not be negative")
+	}
+	return nil
+}
+
+// Rate returns the data rate of the config
+func (c MediumConfig) Rate() (DataRate, error) {
+	index := slices.IndexFunc(DataRates, func(rate DataRate) bool {
+		return rate.Mbps == c.DataRate
+	})
+	if index < 0 {
+		return DataRate{}, fmt.Errorf("unknown data rate: %v Mbps", c.DataRate)
+	}
+	return DataRates[index], nil
+}
+
+// Duration returns how long a frame of size bytes is on the air
+func (c MediumConfig) Duration(size int) time.Duration {
+	return c.Preamble + time.Duration(float64(size*8)/c.DataRate*float64(time.Microsecond))
+}
+
+// Transmission is a frame on the air
+type Transmission struct {
+	ID     uint64
+	Source string
+	Target string
+	Topic  string
+	Start  time.Time
+	End    time.Time
+	// SINRThreshold and NoiseFloor are the ones of the medium when the frame
+	// was sent, a config changed while it is on the air does not apply to it
+	SINRThreshold float64
+	NoiseFloor    float64
+}
+
+// Reception tells how a transmission reached its target
+type Reception struct {
+	Transmission Transmission
+	Status       string
+	// Signal and Interference are in dBm, SINR in dB
+	Signal       float64
+	Interference float64
+	SINR         float64
+	// Interferers sent while the transmission was on the air, the hidden ones
+	// could not be heard by its source
+	Interferers []string
+	Hidden      []string
+}
+
+// MediumStats counts the frames of a device, the sent ones and the ones it
+// was the target of
+type MediumStats struct {
+	Sent       uint64
+	Received   uint64
+	OutOfRange uint64
+	WeakSignal uint64
+	Collisions uint64
+	HalfDuplex uint64
+	// HiddenCollisions are the collisions with a sender the source could not
+	// hear
+	HiddenCollisions uint64
+	// Airtime is the time the device spent sending
+	Airtime time.Duration
+}
+
+// Medium is the air the devices of an environment share, it keeps the frames
+// on it while they can still interfere
+type Medium struct {
+	mu            sync.Mutex
+	config        MediumConfig
+	nextID        uint64
+	transmissions []*mediumTransmission
+	stats         map[string]*MediumStats
+	collisions    []Reception
+}
+
+type mediumTransmission struct {
+	Transmission
+	done bool
+}
+
+func DefaultMediumConfig() MediumConfig {
+	return MediumConfig{
+		Enabled:    false,
+		DataRate:   1,
+		NoiseFloor: -95,
+		Preamble:   192 * time.Microsecond,
+	}
+}
+
+func NewMedium() *Medium {
+	return &Medium{
+		config: DefaultMediumConfig(),
+		stats:  make(map[string]*MediumStats),
+	}
+}
+
+func (m *Medium) GetConfig() MediumConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config
+}
+
+func (m *Medium) SetConfig(config MediumConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.config = config
+	m.mu.Unlock()
+	return nil
+}
+
+// Begin puts a frame of size bytes from source to target on the air
+func (m *Medium) Begin(source, target, topic string, size int, now time.Time) Transmission {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	duration := m.config.Duration(size)
+	rate, _ := m.config.Rate()
+	transmission := &mediumTransmission{
+		Transmission: Transmission{
+			ID:            m.nextID,
+			Source:        source,
+			Target:        target,
+			Topic:         topic,
+			Start:         now,
+			End:           now.Add(duration),
+			SINRThreshold: rate.SINRThreshold,
+			NoiseFloor:    m.config.NoiseFloor,
+		},
+	}
+	m.transmissions = append(m.transmissions, transmission)
+
+	stats := m.deviceStats(source)
+	stats.Sent++
+	stats.Airtime += duration
+
+	return transmission.Transmission
+}
+
+// OnAir returns the transmissions on the air at now
+func (m *Medium) OnAir(now time.Time) []Transmission {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	onAir := make([]Transmission, 0)
+	for _, transmission := range m.transmissions {
+		if !transmission.Start.After(now) && transmission.End.After(now) {
+			onAir = append(onAir, transmission.Transmission)
+		}
+	}
+	return onAir
+}
+
+// overlapping returns the other transmissions on the air at the same time as
+// transmission
+func (m *Medium) overlapping(transmission Transmission) []Transmission {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overlapping := make([]Transmission, 0)
+	for _, other := range m.transmissions {
+		if other.ID == transmission.ID {
+			continue
+		}
+		if other.Start.Before(transmission.End) && other.End.After(transmission.Start) {
+			overlapping = append(overlapping, other.Transmission)
+		}
+	}
+	return overlapping
+}
+
+// finish counts the reception and drops the frames that can no longer
+// interfere with the ones still on the air
+func (m *Medium) finish(reception Reception) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.deviceStats(reception.Transmission.Target)
+	switch reception.Status {
+	case ReceptionOK:
+		stats.Received++
+	case ReceptionOutOfRange:
+		stats.OutOfRange++
+	case ReceptionWeakSignal:
+		stats.WeakSignal++
+	case ReceptionHalfDuplex:
+		stats.HalfDuplex++
+	case ReceptionCollision:
+		stats.Collisions++
+		if len(reception.Hidden) > 0 {
+			stats.HiddenCollisions++
+		}
+	}
+	if reception.Status == ReceptionCollision || reception.Status == ReceptionHalfDuplex {
+		m.collisions = append(m.collisions, reception)
+		if len(m.collisions) > maxCollisions {
+			m.collisions = m.collisions[len(m.collisions)-maxCollisions:]
+		}
+	}
+
+	oldestPending := time.Time{}
+	for _, transmission := range m.transmissions {
+		if transmission.ID == reception.Transmission.ID {
+			transmission.done = true
+		}
+		if !transmission.done && (oldestPending.IsZero() || transmission.Start.Before(oldestPending)) {
+			oldestPending = transmission.Start
+		}
+	}
+	m.transmissions = slices.DeleteFunc(m.transmissions, func(transmission *mediumTransmission) bool {
+		return transmission.done && (oldestPending.IsZero() || transmission.End.Before(oldestPending))
+	})
+}
+
+// deviceStats needs m.mu held
+func (m *Medium) deviceStats(label string) *MediumStats {
+	stats, exists := m.stats[label]
+	if !exists {
+		stats = &MediumStats{}
+		m.stats[label] = stats
+	}
+	return stats
+}
+
+// GetStats returns the counters of every device and the last collisions
+func (m *Medium) GetStats() (map[string]MediumStats, []Reception) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make(map[string]MediumStats, len(m.stats))
+	for label, deviceStats := range m.stats {
+		stats[label] = *deviceStats
+	}
+	return stats, slices.Clone(m.collisions)
+}
+
+// ResetStats clears the counters and the collisions
+func (m *Medium) ResetStats() {
+	m.mu.Lock()
+	m.stats = make(map[string]*MediumStats)
+	m.collisions = nil
+	m.mu.Unlock()
+}
+
+// Receive decides if the target of transmission decoded it, once it is off the
+// air. The power of every frame sent at the same time adds to the noise, the
+// frame is decoded when the SINR reaches the threshold of the data rate it was
+// sent at.
+func (e *Environment) Receive(transmission Transmission) Reception {
+	reception := Reception{
+		Transmission: transmission,
+		Status:       ReceptionOK,
+		Interference: math.Inf(-1),
+		Interferers:  make([]string, 0),
+		Hidden:       make([]string, 0),
+	}
+
+	signal, exists := e.RadioLink(transmission.Source, transmission.Target)
+	reception.Signal = signal.RSSI
+
+	interference := 0.0
+	for _, other := range e.Medium.overlapping(transmission) {
+		if other.Source == transmission.Target {
+			reception.Status = ReceptionHalfDuplex
+		}
+		if other.Source == transmission.Source || other.Source == transmission.Target {
+			continue
+		}
+		link, exists := e.RadioLink(other.Source, transmission.Target)
+		if !exists {
+			continue
+		}
+
+		interference += dBmToMilliwatt(link.RSSI)
+		if !slices.Contains(reception.Interferers, other.Source) {
+			reception.Interferers = append(reception.Interferers, other.Source)
+			if heard, exists := e.RadioLink(other.Source, transmission.Source); !exists || !heard.InRange {
+				reception.Hidden = append(reception.Hidden, other.Source)
+			}
+		}
+	}
+	if interference > 0 {
+		reception.Interference = milliwattToDBm(interference)
+	}
+	reception.SINR = reception.Signal - milliwattToDBm(dBmToMilliwatt(transmission.NoiseFloor)+interference)
+
+	switch {
+	case reception.Status == ReceptionHalfDuplex:
+	case !exists || !signal.InRange:
+		reception.Status = ReceptionOutOfRange
+	case reception.SINR < transmission.SINRThreshold && len(reception.Interferers) == 0:
+		reception.Status = ReceptionWeakSignal
+	case reception.SINR < transmission.SINRThreshold:
+		reception.Status = ReceptionCollision
+	}
+
+	e.Medium.finish(reception)
+	return reception
+}
+
+func dBmToMilliwatt(power float64) float64 {
+	return math.Pow(10, power/10)
+}
+
+func milliwattToDBm(power float64) float64 {
+	return 10 * math.Log10(power)
+}
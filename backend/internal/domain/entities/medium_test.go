@@ -0,0 +1,200 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLinkCapacity(t *testing.T) {
+	tests := []struct {
+		name    string
+		quality LinkQuality
+		want    float64
+	}{
+		{"strong signal gets the fastest rate", LinkQuality{RSSI: -60, ErrorRate: 0}, 54},
+		{"losses lower the capacity", LinkQuality{RSSI: -60, ErrorRate: 0.5}, 27},
+		{"weaker signal adapts the rate", LinkQuality{RSSI: -80, ErrorRate: 0}, 11},
+		{"under every threshold there is no capacity", LinkQuality{RSSI: -93, ErrorRate: 0}, 0},
+		{"out of range links carry nothing", LinkQuality{RSSI: -60, ErrorRate: 1}, 0},
+	}
+	for _, test := range tests {
+		if got := LinkCapacity(test.quality, -95); got != test.want {
+			t.Errorf("%s: capacity is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// mediumTestEnvironment places the devices on a line with the log-distance
+// model and no shadowing. Devices reach about 29 units, 2 units apart the
+// signal is -50 dBm.
+func mediumTestEnvironment(t *testing.T, positions map[string]int) *Environment {
+	t.Helper()
+	environment := NewEnvironment()
+
+	config := DefaultPropagationConfig()
+	config.Model = PropagationLogDistance
+	if err := environment.SetPropagation(config); err != nil {
+		t.Fatal(err)
+	}
+
+	for label, x := range positions {
+		environment.AddDevice(&Device{Label: label, TxPower: DefaultTxPower})
+		environment.SetDeviceInChart(label, CoverageArea{X: x})
+	}
+	return &environment
+}
+
+func TestReceive(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	frame := 100
+
+	tests := []struct {
+		name        string
+		positions   map[string]int
+		dataRate    float64
+		frames      [][2]string
+		status      string
+		interferers []string
+		hidden      []string
+	}{
+		{
+			name:        "a lone frame is decoded",
+			positions:   map[string]int{"A": 0, "B": 2},
+			frames:      [][2]string{{"A", "B"}},
+			status:      ReceptionOK,
+			interferers: []string{},
+			hidden:      []string{},
+		},
+		{
+			name:        "a frame as strong as the signal collides",
+			positions:   map[string]int{"A": 0, "B": 2, "C": 4, "D": 6},
+			frames:      [][2]string{{"A", "B"}, {"C", "D"}},
+			status:      ReceptionCollision,
+			interferers: []string{"C"},
+			hidden:      []string{},
+		},
+		{
+			name:        "the target sending loses the frame",
+			positions:   map[string]int{"A": 0, "B": 2, "C": 4},
+			frames:      [][2]string{{"A", "B"}, {"B", "C"}},
+			status:      ReceptionHalfDuplex,
+			interferers: []string{},
+			hidden:      []string{},
+		},
+		{
+			name:        "an interferer the source can not hear is hidden",
+			positions:   map[string]int{"A": 0, "B": 20, "C": 40},
+			frames:      [][2]string{{"A", "B"}, {"C", "B"}},
+			status:      ReceptionCollision,
+			interferers: []string{"C"},
+			hidden:      []string{"C"},
+		},
+		{
+			name:        "a frame under the sensitivity is out of range",
+			positions:   map[string]int{"A": 0, "B": 40},
+			frames:      [][2]string{{"A", "B"}},
+			status:      ReceptionOutOfRange,
+			interferers: []string{},
+			hidden:      []string{},
+		},
+		{
+			name:        "a lone frame under the threshold of its rate is weak",
+			positions:   map[string]int{"A": 0, "B": 20},
+			dataRate:    54,
+			frames:      [][2]string{{"A", "B"}},
+			status:      ReceptionWeakSignal,
+			interferers: []string{},
+			hidden:      []string{},
+		},
+	}
+	for _, test := range tests {
+		environment := mediumTestEnvironment(t, test.positions)
+		if test.dataRate != 0 {
+			config := DefaultMediumConfig()
+			config.DataRate = test.dataRate
+			if err := environment.Medium.SetConfig(config); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		transmissions := make([]Transmission, 0, len(test.frames))
+		for i, frames := range test.frames {
+			start := now.Add(time.Duration(i) * 100 * time.Microsecond)
+			transmissions = append(transmissions, environment.Medium.Begin(frames[0], frames[1], "test", frame, start))
+		}
+
+		reception := environment.Receive(transmissions[0])
+		if reception.Status != test.status {
+			t.Errorf("%s: status is %s, want %s (sinr %.1f)", test.name, reception.Status, test.status, reception.SINR)
+		}
+		if !slices.Equal(reception.Interferers, test.interferers) || !slices.Equal(reception.Hidden, test.hidden) {
+			t.Errorf("%s: interferers %v and hidden %v, want %v and %v", test.name, reception.Interferers, reception.Hidden, test.interferers, test.hidden)
+		}
+	}
+}
+
+func TestReceiveKeepsTheConfigOfTheFrame(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	environment := mediumTestEnvironment(t, map[string]int{"A": 0, "B": 2})
+
+	transmission := environment.Medium.Begin("A", "B", "test", 100, now)
+	if transmission.SINRThreshold != 4 || transmission.NoiseFloor != -95 {
+		t.Fatal("the frame should keep the threshold and the noise of the medium", transmission)
+	}
+
+	config := DefaultMediumConfig()
+	config.NoiseFloor = -40
+	config.DataRate = 54
+	if err := environment.Medium.SetConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	if reception := environment.Receive(transmission); reception.Status != ReceptionOK {
+		t.Error("a config changed on the air should not apply to the frame", reception.Status, reception.SINR)
+	}
+}
+
+func TestMediumPrune(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	environment := mediumTestEnvironment(t, map[string]int{"A": 0, "B": 2, "C": 4, "D": 6})
+	medium := environment.Medium
+
+	// 100 bytes take 992µs at 1 Mbps
+	first := medium.Begin("A", "B", "test", 100, now)
+	second := medium.Begin("C", "D", "test", 100, now.Add(500*time.Microsecond))
+	third := medium.Begin("A", "B", "test", 100, now.Add(1200*time.Microsecond))
+
+	ids := func() []uint64 {
+		medium.mu.Lock()
+		defer medium.mu.Unlock()
+		ids := make([]uint64, 0, len(medium.transmissions))
+		for _, transmission := range medium.transmissions {
+			ids = append(ids, transmission.ID)
+		}
+		return ids
+	}
+
+	environment.Receive(first)
+	if got := ids(); !slices.Equal(got, []uint64{first.ID, second.ID, third.ID}) {
+		t.Error("a finished frame that overlaps a pending one should be kept", got)
+	}
+
+	if reception := environment.Receive(second); !slices.Equal(reception.Interferers, []string{"A"}) {
+		t.Error("the frames kept should still interfere", reception.Interferers)
+	}
+	if got := ids(); !slices.Equal(got, []uint64{second.ID, third.ID}) {
+		t.Error("a finished frame that ended before every pending one should be dropped", got)
+	}
+
+	environment.Receive(third)
+	if got := ids(); len(got) != 0 {
+		t.Error("every frame should be dropped once none is pending", got)
+	}
+
+	stats, collisions := medium.GetStats()
+	// A is far enough from D for the second frame to get through
+	if stats["B"].Collisions != 2 || stats["D"].Received != 1 || len(collisions) != 2 {
+		t.Error("wrong collision counts", stats, len(collisions))
+	}
+}
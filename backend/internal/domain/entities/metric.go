@@ -0,0 +1,157 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// MetricCombine tells how the link values of a metric combine along a path
+type MetricCombine string
+
+const (
+	// CombineAdditive sums the link values, the best path has the smallest sum
+	CombineAdditive MetricCombine = "additive"
+	// CombineMultiplicative multiplies the link values, the best path has the
+	// smallest product. Link values must be at least 1, e.g. the expected
+	// transmissions of a link.
+	CombineMultiplicative MetricCombine = "multiplicative"
+	// CombineBottleneck keeps the smallest link value, the best path has the
+	// largest one, e.g. the capacity of a path
+	CombineBottleneck MetricCombine = "bottleneck"
+)
+
+// Metric is a way of weighting the links between devices, every metric is a
+// routing type of the routing tables
+type Metric struct {
+	Name        string
+	Description string
+	Combine     MetricCombine
+	// ContentTypes are the content types routed with this metric by default
+	ContentTypes []string
+	// Weight computes the value of the link from device to deviceConn
+	Weight func(environment *Environment, device *Device, deviceConn string) (float64, error)
+	// Heuristic estimates the arc weights of the path from source to target,
+	// paths are searched with A* when it is set. It must never be greater than
+	// the sum of the arcs of any path, so only additive metrics with a lower
+	// bound on their link values can have one.
+	Heuristic func(environment *Environment, source, target string) uint64
+}
+
+const (
+	// arcScale is the amount of arc weight units of a link value unit
+	arcScale = 1000
+	// logArcScale is the scale of the logarithms of multiplicative metrics,
+	// finer since the logarithm of a good link is close to 0
+	logArcScale = 1_000_000
+)
+
+// ArcScale returns the amount of arc weight units of a unit of what the arcs
+// of the metric add up
+func (m Metric) ArcScale() float64 {
+	if m.Combine == CombineMultiplicative {
+		return logArcScale
+	}
+	return arcScale
+}
+
+// ArcWeight converts a link value to the weight of the arc in a path graph,
+// so paths are compared by the sum of their arcs. Multiplicative metrics use
+// the logarithm of the value. Every link of a path costs at least 1, so no
+// path is free. Bottleneck metrics keep the scaled value, their paths are
+// compared by the smallest arc instead.
+func (m Metric) ArcWeight(value float64) uint64 {
+	switch m.Combine {
+	case CombineMultiplicative:
+		return max(uint64(math.Log(max(value, 1))*m.ArcScale()), 1)
+	case CombineAdditive:
+		return max(uint64(value*m.ArcScale()), 1)
+	default:
+		return uint64(value * m.ArcScale())
+	}
+}
+
+// PathValue converts the sum of the arcs of a path back to the unit of the
+// link values, the product of the link values for multiplicative metrics
+func (m Metric) PathValue(weight uint64) float64 {
+	if m.Combine == CombineMultiplicative {
+		return math.Exp(float64(weight) / m.ArcScale())
+	}
+	return float64(weight) / m.ArcScale()
+}
+
+// MetricRegistry keeps the metrics in the order they were registered
+type MetricRegistry struct {
+	mu      sync.RWMutex
+	metrics []Metric
+}
+
+// Register adds metric to the registry, the name must be unique
+func (r *MetricRegistry) Register(metric Metric) error {
+	switch metric.Combine {
+	case CombineAdditive, CombineMultiplicative, CombineBottleneck:
+	default:
+		return fmt.Errorf("unknown metric combine: %s", metric.Combine)
+	}
+	if metric.Name == "" || metric.Weight == nil {
+		return fmt.Errorf("metric needs a name and a weight")
+	}
+	if metric.Heuristic != nil && metric.Combine != CombineAdditive {
+		return fmt.Errorf("only additive metrics can have a heuristic: %s", metric.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slices.ContainsFunc(r.metrics, func(m Metric) bool { return m.Name == metric.Name }) {
+		return fmt.Errorf("metric already registered: %s", metric.Name)
+	}
+	r.metrics = append(r.metrics, metric)
+
+	return nil
+}
+
+// Get returns the metric named name
+func (r *MetricRegistry) Get(name string) (Metric, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := slices.IndexFunc(r.metrics, func(m Metric) bool { return m.Name == name })
+	if i < 0 {
+		return Metric{}, false
+	}
+	return r.metrics[i], true
+}
+
+// List returns every metric in the order they were registered
+func (r *MetricRegistry) List() []Metric {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.metrics)
+}
+
+// Names returns the names of every metric in the order they were registered
+func (r *MetricRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.metrics))
+	for _, metric := range r.metrics {
+		names = append(names, metric.Name)
+	}
+	return names
+}
+
+// ForContentType returns the first metric that routes contentType by default
+func (r *MetricRegistry) ForContentType(contentType string) (Metric, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, metric := range r.metrics {
+		if slices.Contains(metric.ContentTypes, contentType) {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArcWeight(t *testing.T) {
+	additive := Metric{Combine: CombineAdditive}
+	multiplicative := Metric{Combine: CombineMultiplicative}
+	bottleneck := Metric{Combine: CombineBottleneck}
+
+	tests := []struct {
+		name   string
+		metric Metric
+		value  float64
+		want   uint64
+	}{
+		{"additive value", additive, 2.5, 2500},
+		{"additive links are never free", additive, 0, 1},
+		{"multiplicative value", multiplicative, math.E, 1_000_000},
+		{"multiplicative small loss", multiplicative, 1 / (1 - 0.0005), 500},
+		{"multiplicative perfect link is never free", multiplicative, 1, 1},
+		{"multiplicative values under 1 are clamped", multiplicative, 0.5, 1},
+		{"bottleneck value", bottleneck, 54, 54000},
+		{"bottleneck keeps empty links", bottleneck, 0, 0},
+	}
+	for _, test := range tests {
+		if got := test.metric.ArcWeight(test.value); got != test.want {
+			t.Errorf("%s: arc weight is %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestPathValue(t *testing.T) {
+	additive := Metric{Combine: CombineAdditive}
+	if got := additive.PathValue(additive.ArcWeight(1.5) + additive.ArcWeight(2)); got != 3.5 {
+		t.Error("additive path should sum its links, got", got)
+	}
+
+	multiplicative := Metric{Combine: CombineMultiplicative}
+	got := multiplicative.PathValue(multiplicative.ArcWeight(2) + multiplicative.ArcWeight(1.5))
+	if math.Abs(got-3) > 1e-5 {
+		t.Error("multiplicative path should multiply its links, got", got)
+	}
+}
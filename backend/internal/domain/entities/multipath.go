@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// MultipathSingle sends every message over the shortest path
+	MultipathSingle = "single"
+	// MultipathECMP spreads the messages over the paths of the shortest
+	// distance
+	MultipathECMP = "ecmp"
+	// MultipathWeighted spreads the messages over the k shortest paths within
+	// the variance of the shortest one, the shorter the path the more it gets
+	MultipathWeighted = "weighted"
+)
+
+const (
+	// BalanceFlow keeps every message of a flow on the same path
+	BalanceFlow = "flow"
+	// BalancePacket picks a path for every message
+	BalancePacket = "packet"
+)
+
+// MultipathConfig tells how the user messages are spread over the paths to
+// their destination
+type MultipathConfig struct {
+	Mode      string
+	Balancing string
+	// MaxPaths is the most paths a flow is spread over
+	MaxPaths int
+	// Variance is how many times longer than the shortest path a path can be
+	// in the weighted mode
+	Variance float64
+}
+
+func (c MultipathConfig) Validate() error {
+	switch c.Mode {
+	case MultipathSingle, MultipathECMP, MultipathWeighted:
+	default:
+		return fmt.Errorf("unknown multipath mode: %s", c.Mode)
+	}
+	switch c.Balancing {
+	case BalanceFlow, BalancePacket:
+	default:
+		return fmt.Errorf("unknown load balancing: %s", c.Balancing)
+	}
+	if c.MaxPaths < 1 {
+		return fmt.Errorf("max paths must be at least 1")
+	}
+	if c.Variance < 1 {
+		return fmt.Errorf("variance must be at least 1")
+	}
+	return nil
+}
+
+// PathLoad is the amount of messages sent over a path
+type PathLoad struct {
+	Source   string
+	Target   string
+	Path     []string
+	Messages uint64
+}
+
+// Multipath keeps the multipath config of an environment and counts the
+// messages sent over every path
+type Multipath struct {
+	mu     sync.Mutex
+	config MultipathConfig
+	load   map[string]*PathLoad
+}
+
+func NewMultipath() *Multipath {
+	return &Multipath{
+		config: MultipathConfig{
+			Mode:      MultipathSingle,
+			Balancing: BalanceFlow,
+			MaxPaths:  4,
+			Variance:  2,
+		},
+		load: make(map[string]*PathLoad),
+	}
+}
+
+func (m *Multipath) GetConfig() MultipathConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.config
+}
+
+func (m *Multipath) SetConfig(config MultipathConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.config = config
+	m.mu.Unlock()
+	return nil
+}
+
+// Record counts a message sent over path
+func (m *Multipath) Record(path []string) {
+	if len(path) < 2 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.Join(path, ",")
+	load, exists := m.load[key]
+	if !exists {
+		load = &PathLoad{
+			Source: path[0],
+			Target: path[len(path)-1],
+			Path:   slices.Clone(path),
+		}
+		m.load[key] = load
+	}
+	load.Messages++
+}
+
+// GetLoad returns the messages sent over every path, sorted by source, target
+// and the most used path first
+func (m *Multipath) GetLoad() []PathLoad {
+	m.mu.Lock()
+	load := make([]PathLoad, 0, len(m.load))
+	for _, pathLoad := range m.load {
+		pathLoad := *pathLoad
+		pathLoad.Path = slices.Clone(pathLoad.Path)
+		load = append(load, pathLoad)
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(load, func(a, b PathLoad) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(b.Messages, a.Messages),
+			slices.Compare(a.Path, b.Path),
+		)
+	})
+	return load
+}
+
+// ResetLoad clears the message counters
+func (m *Multipath) ResetLoad() {
+	m.mu.Lock()
+	m.load = make(map[string]*PathLoad)
+	m.mu.Unlock()
+}
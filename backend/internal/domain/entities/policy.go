@@ -0,0 +1,184 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ReliabilityAcknowledged has every hop acknowledge the message
+	ReliabilityAcknowledged = "acknowledged"
+	// ReliabilityBestEffort forwards the message without acknowledgements
+	ReliabilityBestEffort = "best-effort"
+)
+
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+)
+
+// priorityRank orders the priority classes, the higher the sooner a message
+// is processed
+var priorityRank = map[string]int{
+	PriorityLow:    0,
+	PriorityNormal: 1,
+	PriorityHigh:   2,
+}
+
+// PriorityRank returns the rank of the priority class, messages of a higher
+// rank are processed first. Unknown classes rank as normal.
+func PriorityRank(priority string) int {
+	if rank, exists := priorityRank[priority]; exists {
+		return rank
+	}
+	return priorityRank[PriorityNormal]
+}
+
+// RoutingPolicy chooses how the messages it matches are routed. Empty match
+// fields match anything.
+type RoutingPolicy struct {
+	ID               uuid.UUID
+	ContentType      string
+	SenderGroup      string
+	DestinationGroup string
+	// Metric routes the message, FallbackMetric is tried when Metric has no
+	// route to the destination
+	Metric         string
+	FallbackMetric string
+	Reliability    string
+	Priority       string
+}
+
+// Validate checks the reliability mode and the priority class, the metrics
+// are checked by whoever knows them
+func (p RoutingPolicy) Validate() error {
+	if p.Metric == "" {
+		return fmt.Errorf("routing policy needs a metric")
+	}
+	switch p.Reliability {
+	case ReliabilityAcknowledged, ReliabilityBestEffort:
+	default:
+		return fmt.Errorf("unknown reliability mode: %s", p.Reliability)
+	}
+	if _, exists := priorityRank[p.Priority]; !exists {
+		return fmt.Errorf("unknown priority class: %s", p.Priority)
+	}
+	return nil
+}
+
+// Matches tells if the policy applies to a message of contentType sent from a
+// device of senderGroup to a device of destinationGroup
+func (p RoutingPolicy) Matches(contentType, senderGroup, destinationGroup string) bool {
+	return (p.ContentType == "" || p.ContentType == contentType) &&
+		(p.SenderGroup == "" || p.SenderGroup == senderGroup) &&
+		(p.DestinationGroup == "" || p.DestinationGroup == destinationGroup)
+}
+
+// specificity is the amount of match fields the policy sets
+func (p RoutingPolicy) specificity() int {
+	specificity := 0
+	for _, field := range []string{p.ContentType, p.SenderGroup, p.DestinationGroup} {
+		if field != "" {
+			specificity++
+		}
+	}
+	return specificity
+}
+
+// RoutingPolicies are the routing policies of an environment, in the order
+// they were added
+type RoutingPolicies struct {
+	mu       sync.Mutex
+	policies []RoutingPolicy
+}
+
+func NewRoutingPolicies(policies ...RoutingPolicy) *RoutingPolicies {
+	return &RoutingPolicies{policies: slices.Clone(policies)}
+}
+
+// List returns every policy in the order they were added
+func (r *RoutingPolicies) List() []RoutingPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.policies)
+}
+
+// Get returns the policy with the id
+func (r *RoutingPolicies) Get(id uuid.UUID) (RoutingPolicy, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return RoutingPolicy{}, false
+	}
+	return r.policies[i], true
+}
+
+// Add appends policy with a new id and returns it
+func (r *RoutingPolicies) Add(policy RoutingPolicy) RoutingPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policy.ID = uuid.New()
+	r.policies = append(r.policies, policy)
+	return policy
+}
+
+// Update replaces the policy with the id of policy, keeping its place
+func (r *RoutingPolicies) Update(policy RoutingPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(policy.ID)
+	if i < 0 {
+		return fmt.Errorf("routing policy not found: %s", policy.ID)
+	}
+	r.policies[i] = policy
+	return nil
+}
+
+// Remove deletes the policy with the id
+func (r *RoutingPolicies) Remove(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(id)
+	if i < 0 {
+		return fmt.Errorf("routing policy not found: %s", id)
+	}
+	r.policies = slices.Delete(r.policies, i, i+1)
+	return nil
+}
+
+// Match returns the policy for a message of contentType sent from a device of
+// senderGroup to a device of destinationGroup. The policy that sets the most
+// match fields wins, and the one added first between equally specific ones.
+func (r *RoutingPolicies) Match(contentType, senderGroup, destinationGroup string) (RoutingPolicy, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	best := -1
+	for i, policy := range r.policies {
+		if !policy.Matches(contentType, senderGroup, destinationGroup) {
+			continue
+		}
+		if best < 0 || policy.specificity() > r.policies[best].specificity() {
+			best = i
+		}
+	}
+	if best < 0 {
+		return RoutingPolicy{}, false
+	}
+	return r.policies[best], true
+}
+
+func (r *RoutingPolicies) index(id uuid.UUID) int {
+	return slices.IndexFunc(r.policies, func(policy RoutingPolicy) bool {
+		return policy.ID == id
+	})
+}
@@ -0,0 +1,64 @@
+package entities
+
+import "testing"
+
+func TestRoutingPoliciesMatch(t *testing.T) {
+	policies := NewRoutingPolicies(
+		RoutingPolicy{Metric: "any"},
+		RoutingPolicy{ContentType: "video", Metric: "video"},
+		RoutingPolicy{SenderGroup: "cameras", Metric: "cameras"},
+		RoutingPolicy{ContentType: "video", SenderGroup: "cameras", Metric: "camera-video"},
+		RoutingPolicy{ContentType: "video", SenderGroup: "cameras", Metric: "camera-video-later"},
+		RoutingPolicy{DestinationGroup: "sinks", Metric: "sinks"},
+	)
+
+	tests := []struct {
+		name                                       string
+		contentType, senderGroup, destinationGroup string
+		want                                       string
+	}{
+		{"empty fields match anything", "text", "sensors", "hub", "any"},
+		{"empty message fields match the catch all", "", "", "", "any"},
+		{"one field beats none", "video", "sensors", "hub", "video"},
+		{"the sender group beats none", "text", "cameras", "hub", "cameras"},
+		{"the most specific wins", "video", "cameras", "hub", "camera-video"},
+		{"the earliest wins a tie", "video", "sensors", "sinks", "video"},
+	}
+	for _, test := range tests {
+		policy, matched := policies.Match(test.contentType, test.senderGroup, test.destinationGroup)
+		if !matched || policy.Metric != test.want {
+			t.Errorf("%s: matched %v with %q, want %q", test.name, matched, policy.Metric, test.want)
+		}
+	}
+
+	if _, matched := NewRoutingPolicies(RoutingPolicy{ContentType: "video", Metric: "video"}).Match("text", "", ""); matched {
+		t.Error("a policy for another content type should not match")
+	}
+	if _, matched := NewRoutingPolicies().Match("text", "", ""); matched {
+		t.Error("no policy should match with no policies")
+	}
+}
+
+func TestRoutingPolicyValidate(t *testing.T) {
+	valid := RoutingPolicy{Metric: "distance", Reliability: ReliabilityAcknowledged, Priority: PriorityNormal}
+
+	tests := []struct {
+		name   string
+		modify func(policy *RoutingPolicy)
+		valid  bool
+	}{
+		{"valid", func(policy *RoutingPolicy) {}, true},
+		{"best effort", func(policy *RoutingPolicy) { policy.Reliability = ReliabilityBestEffort }, true},
+		{"no metric", func(policy *RoutingPolicy) { policy.Metric = "" }, false},
+		{"unknown reliability", func(policy *RoutingPolicy) { policy.Reliability = "sometimes" }, false},
+		{"no reliability", func(policy *RoutingPolicy) { policy.Reliability = "" }, false},
+		{"unknown priority", func(policy *RoutingPolicy) { policy.Priority = "urgent" }, false},
+	}
+	for _, test := range tests {
+		policy := valid
+		test.modify(&policy)
+		if err := policy.Validate(); (err == nil) != test.valid {
+			t.Errorf("%s: validate returned %v, want valid %v", test.name, err, test.valid)
+		}
+	}
+}
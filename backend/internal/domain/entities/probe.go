@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// ProbeInterval is how often every device broadcasts a probe to the
+	// devices in its coverage area
+	ProbeInterval = 5 * time.Second
+	// ProbeWindow is how far back the delivery ratios look
+	ProbeWindow = 10 * ProbeInterval
+)
+
+// ProbeStats counts the probes received from a neighbour over a sliding
+// window, and keeps the ratio of our own probes the neighbour reported
+type ProbeStats struct {
+	received []time.Time
+	since    time.Time
+	// Reported is the ratio of our probes the neighbour said it received
+	Reported   float64
+	ReportedAt time.Time
+}
+
+// Receive records a probe received at now with the ratio of our probes the
+// neighbour received
+func (p *ProbeStats) Receive(reported float64, now time.Time) {
+	p.trim(now)
+	if len(p.received) == 0 {
+		p.since = now
+	}
+	p.received = append(p.received, now)
+	p.Reported = reported
+	p.ReportedAt = now
+}
+
+// Ratio returns the ratio of the probes of the neighbour received in the
+// window, the probes expected are counted from the first one received since
+// the neighbour was last heard
+func (p *ProbeStats) Ratio(now time.Time) float64 {
+	p.trim(now)
+	if len(p.received) == 0 {
+		return 0
+	}
+
+	observed := min(now.Sub(p.since), ProbeWindow)
+	expected := math.Max(1, math.Floor(float64(observed)/float64(ProbeInterval))+1)
+
+	return math.Min(1, float64(len(p.received))/expected)
+}
+
+// ETX is the expected amount of transmissions to deliver a frame over the
+// link and get it acknowledged, 1 / (forward ratio * reverse ratio). It is
+// false while either ratio is unknown.
+func (p *ProbeStats) ETX(now time.Time) (float64, bool) {
+	reverse := p.Ratio(now)
+	if reverse == 0 || p.Reported == 0 || now.Sub(p.ReportedAt) > ProbeWindow {
+		return 0, false
+	}
+
+	return 1 / (p.Reported * reverse), true
+}
+
+func (p *ProbeStats) trim(now time.Time) {
+	i := 0
+	for i < len(p.received) && now.Sub(p.received[i]) > ProbeWindow {
+		i++
+	}
+	p.received = p.received[i:]
+}
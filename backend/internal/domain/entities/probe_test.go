@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// probeStats returns the stats of a neighbour whose probes were received at
+// the given intervals from start, reporting reported
+func probeStats(start time.Time, intervals []int, reported float64) *ProbeStats {
+	stats := &ProbeStats{}
+	for _, interval := range intervals {
+		stats.Receive(reported, start.Add(time.Duration(interval)*ProbeInterval))
+	}
+	return stats
+}
+
+func TestProbeRatio(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		intervals []int
+		at        int
+		want      float64
+	}{
+		{"nothing received", []int{}, 0, 0},
+		{"first probe just received", []int{0}, 0, 1},
+		{"every probe received", []int{0, 1, 2, 3}, 3, 1},
+		{"one probe of three received", []int{0}, 2, 1.0 / 3},
+		{"every other probe lost", []int{0, 2, 4, 6}, 6, 4.0 / 7},
+		{"expected capped by the window", []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20}, 20, 6.0 / 11},
+		{"probes older than the window are trimmed", []int{0, 1}, 12, 0},
+	}
+	for _, test := range tests {
+		stats := probeStats(start, test.intervals, 1)
+		at := start.Add(time.Duration(test.at) * ProbeInterval)
+		if got := stats.Ratio(at); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%s: ratio is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestProbeETX(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := start.Add(3 * ProbeInterval)
+
+	tests := []struct {
+		name       string
+		intervals  []int
+		reported   float64
+		reportedAt time.Time
+		want       float64
+		known      bool
+	}{
+		{"both ratios known", []int{0, 1, 2, 3}, 0.5, at, 2, true},
+		{"both ratios lossy", []int{0, 2}, 0.5, at, 4, true},
+		{"report at the window", []int{0, 1, 2, 3}, 0.5, at.Add(-ProbeWindow), 2, true},
+		{"report older than the window", []int{0, 1, 2, 3}, 0.5, at.Add(-ProbeWindow - time.Second), 0, false},
+		{"nothing reported", []int{0, 1, 2, 3}, 0, at, 0, false},
+		{"nothing received", []int{}, 0.5, at, 0, false},
+	}
+	for _, test := range tests {
+		stats := probeStats(start, test.intervals, test.reported)
+		stats.Reported = test.reported
+		stats.ReportedAt = test.reportedAt
+
+		got, known := stats.ETX(at)
+		if known != test.known || math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("%s: etx is %v (known %v), want %v (known %v)", test.name, got, known, test.want, test.known)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package entities
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+const (
+	// PropagationDisc links the devices inside the coverage radius of the
+	// sender, the radius being its power
+	PropagationDisc = "disc"
+	// PropagationFreeSpace is the free-space path loss
+	PropagationFreeSpace = "free-space"
+	// PropagationLogDistance grows the loss with the path-loss exponent past
+	// the reference distance
+	PropagationLogDistance = "log-distance"
+	// PropagationShadowing adds a log-normal shadowing to the log-distance
+	// loss, fixed for every link while its devices do not move
+	PropagationShadowing = "shadowing"
+	// PropagationTwoRay is the two-ray ground reflection, free-space up to the
+	// crossover distance
+	PropagationTwoRay = "two-ray"
+)
+
+const (
+	// DefaultTxPower is the transmit power of the devices that do not set one,
+	// in dBm
+	DefaultTxPower = 20.0
+	// DefaultSensitivity is the receiver sensitivity of the devices that do
+	// not set one, in dBm
+	DefaultSensitivity = -85.0
+	// sensitivityErrorRate is the frame error rate at the sensitivity
+	sensitivityErrorRate = 0.1
+)
+
+// PropagationModel computes how much a signal weakens over a distance
+type PropagationModel interface {
+	// PathLoss returns the loss in dB over distance meters. sample is a
+	// standard normal sample fixed for the link, models with no randomness
+	// ignore it.
+	PathLoss(distance, sample float64) float64
+}
+
+// PropagationConfig selects the propagation model of an environment and its
+// parameters
+type PropagationConfig struct {
+	Model        string
+	FrequencyMHz float64
+	// PathLossExponent is 2 in free space, 2.7 to 5 in urban areas and
+	// buildings
+	PathLossExponent float64
+	// ReferenceDistance is where the log-distance loss starts to grow with the
+	// exponent, in meters
+	ReferenceDistance float64
+	// ShadowingSigma is the standard deviation of the shadowing, in dB
+	ShadowingSigma float64
+	// AntennaHeight is the height of every antenna for the two-ray model, in
+	// meters
+	AntennaHeight float64
+	// MetersPerUnit is the length of a chart unit
+	MetersPerUnit float64
+}
+
+func DefaultPropagationConfig() PropagationConfig {
+	return PropagationConfig{
+		Model:             PropagationDisc,
+		FrequencyMHz:      2400,
+		PathLossExponent:  3,
+		ReferenceDistance: 1,
+		ShadowingSigma:    4,
+		AntennaHeight:     1.5,
+		MetersPerUnit:     5,
+	}
+}
+
+// PropagationModel builds the model of the config, nil for the disc
+func (c PropagationConfig) PropagationModel() (PropagationModel, error) {
+	if c.Model != PropagationDisc && (c.FrequencyMHz <= 0 || c.MetersPerUnit <= 0) {
+		return nil, fmt.Errorf("frequency and meters per unit must be positive")
+	}
+
+	freeSpace := FreeSpace{FrequencyMHz: c.FrequencyMHz}
+	logDistance := LogDistance{
+		FreeSpace:         freeSpace,
+		Exponent:          c.PathLossExponent,
+		ReferenceDistance: c.ReferenceDistance,
+	}
+
+	switch c.Model {
+	case PropagationDisc:
+		return nil, nil
+	case PropagationFreeSpace:
+		return freeSpace, nil
+	case PropagationLogDistance, PropagationShadowing:
+		if c.PathLossExponent <= 0 || c.ReferenceDistance <= 0 {
+			return nil, fmt.Errorf("path loss exponent and reference distance must be positive")
+		}
+		if c.Model == PropagationLogDistance {
+			return logDistance, nil
+		}
+		if c.ShadowingSigma < 0 {
+			return nil, fmt.Errorf("shadowing sigma can not be negative")
+		}
+		return Shadowing{LogDistance: logDistance, Sigma: c.ShadowingSigma}, nil
+	case PropagationTwoRay:
+		if c.AntennaHeight <= 0 {
+			return nil, fmt.Errorf("antenna height must be positive")
+		}
+		return TwoRay{FreeSpace: freeSpace, TransmitterHeight: c.AntennaHeight, ReceiverHeight: c.AntennaHeight}, nil
+	default:
+		return nil, fmt.Errorf("unknown propagation model: %s", c.Model)
+	}
+}
+
+type FreeSpace struct {
+	FrequencyMHz float64
+}
+
+func (m FreeSpace) PathLoss(distance, sample float64) float64 {
+	// the loss is 0 dB under a meter instead of a gain
+	return 20*math.Log10(math.Max(distance, 1)) + 20*math.Log10(m.FrequencyMHz) - 27.55
+}
+
+type LogDistance struct {
+	FreeSpace
+	Exponent          float64
+	ReferenceDistance float64
+}
+
+func (m LogDistance) PathLoss(distance, sample float64) float64 {
+	reference := m.FreeSpace.PathLoss(m.ReferenceDistance, sample)
+	if distance <= m.ReferenceDistance {
+		return reference
+	}
+	return reference + 10*m.Exponent*math.Log10(distance/m.ReferenceDistance)
+}
+
+type Shadowing struct {
+	LogDistance
+	Sigma float64
+}
+
+func (m Shadowing) PathLoss(distance, sample float64) float64 {
+	return m.LogDistance.PathLoss(distance, sample) + m.Sigma*sample
+}
+
+type TwoRay struct {
+	FreeSpace
+	TransmitterHeight float64
+	ReceiverHeight    float64
+}
+
+func (m TwoRay) PathLoss(distance, sample float64) float64 {
+	wavelength := 299.792458 / m.FrequencyMHz
+	crossover := 4 * math.Pi * m.TransmitterHeight * m.ReceiverHeight / wavelength
+	if distance <= crossover {
+		return m.FreeSpace.PathLoss(distance, sample)
+	}
+	return 40*math.Log10(distance) - 20*math.Log10(m.TransmitterHeight) - 20*math.Log10(m.ReceiverHeight)
+}
+
+// RadioLink is what a receiver gets from a sender
+type RadioLink struct {
+	Source string
+	Target string
+	// Distance is in chart units
+	Distance float64
+	// RSSI is the received signal strength, in dBm
+	RSSI float64
+	// InRange tells if the receiver can decode the signal at all
+	InRange   bool
+	ErrorRate float64
+}
+
+// ErrorRateFromMargin returns the frame error rate of a signal received margin
+// dB over the sensitivity. It is 10% at the sensitivity and drops tenfold
+// every 5 dB over it.
+func ErrorRateFromMargin(margin float64) float64 {
+	return 1 / (1 + (1/sensitivityErrorRate-1)*math.Pow(10, margin/5))
+}
+
+// linkSample returns the standard normal sample of the link between two
+// devices at their positions, so the shadowing of a link only changes when
+// one of them moves. The hash of the link is mapped to a uniform number in
+// (0, 1) and through the inverse normal distribution, nothing is allocated
+// on the way.
+func linkSample(source string, sourcePosition CoverageArea, target string, targetPosition CoverageArea) float64 {
+	if target < source {
+		source, target = target, source
+		sourcePosition, targetPosition = targetPosition, sourcePosition
+	}
+
+	var buffer [64]byte
+	key := buffer[:0]
+	for _, end := range []struct {
+		label    string
+		position CoverageArea
+	}{{source, sourcePosition}, {target, targetPosition}} {
+		key = append(key, end.label...)
+		key = append(key, 0)
+		key = binary.LittleEndian.AppendUint64(key, uint64(end.position.X))
+		key = binary.LittleEndian.AppendUint64(key, uint64(end.position.Y))
+	}
+
+	hash := fnv.New64a()
+	hash.Write(key)
+
+	uniform := (float64(hash.Sum64()>>11) + 0.5) / (1 << 53)
+	return math.Sqrt2 * math.Erfinv(2*uniform-1)
+}
+
+// Range returns the distance in chart units at which a signal sent with
+// txPower drops to sensitivity, leaving the shadowing out
+func (c PropagationConfig) Range(model PropagationModel, txPower, sensitivity float64) float64 {
+	budget := txPower - sensitivity
+	if model.PathLoss(0, 0) > budget {
+		return 0
+	}
+
+	// the loss grows with the distance, so the range is found by bisection
+	low, high := 0.0, 1.0
+	for model.PathLoss(high, 0) <= budget && high < 1e7 {
+		low, high = high, high*2
+	}
+	for range 50 {
+		middle := (low + high) / 2
+		if model.PathLoss(middle, 0) <= budget {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	return low / c.MetersPerUnit
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestLinkSample(t *testing.T) {
+	a, b := CoverageArea{X: 1, Y: 2}, CoverageArea{X: 3, Y: 4}
+
+	sample := linkSample("A", a, "B", b)
+	if linkSample("A", a, "B", b) != sample {
+		t.Error("the sample of a link should not change while its devices stay")
+	}
+	if linkSample("B", b, "A", a) != sample {
+		t.Error("both directions of a link should have the same sample")
+	}
+	if linkSample("A", CoverageArea{X: 1, Y: 3}, "B", b) == sample {
+		t.Error("the sample should change when a device moves")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		linkSample("A", a, "B", b)
+	})
+	if allocs > 0 {
+		t.Error("the sample should not allocate, got", allocs)
+	}
+
+	var sum, squares float64
+	const links = 10000
+	for i := range links {
+		sample := linkSample(fmt.Sprint("D", i), a, "Z", b)
+		sum += sample
+		squares += sample * sample
+	}
+	mean := sum / links
+	deviation := math.Sqrt(squares/links - mean*mean)
+	if math.Abs(mean) > 0.05 || math.Abs(deviation-1) > 0.05 {
+		t.Errorf("samples should be standard normal, got mean %v and deviation %v", mean, deviation)
+	}
+}
+
+// propagationModels returns the models of the default config, by name
+func propagationModels(t *testing.T) map[string]PropagationModel {
+	t.Helper()
+	models := make(map[string]PropagationModel)
+	for _, name := range []string{PropagationFreeSpace, PropagationLogDistance, PropagationShadowing, PropagationTwoRay} {
+		config := DefaultPropagationConfig()
+		config.Model = name
+		model, err := config.PropagationModel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		models[name] = model
+	}
+	return models
+}
+
+func TestPathLoss(t *testing.T) {
+	models := propagationModels(t)
+
+	// 20 log10(2400) - 27.55 at a meter
+	const oneMeter = 40.0542
+
+	tests := []struct {
+		name     string
+		model    string
+		distance float64
+		sample   float64
+		want     float64
+	}{
+		{"free space at a meter", PropagationFreeSpace, 1, 0, oneMeter},
+		{"free space under a meter is no gain", PropagationFreeSpace, 0.1, 0, oneMeter},
+		{"free space grows 20 dB a decade", PropagationFreeSpace, 100, 0, oneMeter + 40},
+		{"log distance at the reference", PropagationLogDistance, 1, 0, oneMeter},
+		{"log distance grows 30 dB a decade", PropagationLogDistance, 100, 0, oneMeter + 60},
+		{"shadowing adds sigma times the sample", PropagationShadowing, 100, 1, oneMeter + 64},
+		{"shadowing with no sample is log distance", PropagationShadowing, 100, 0, oneMeter + 60},
+		{"two ray is free space before the crossover", PropagationTwoRay, 100, 0, oneMeter + 40},
+		{"two ray past the crossover", PropagationTwoRay, 1000, 0, 120 - 40*math.Log10(1.5)},
+	}
+	for _, test := range tests {
+		if got := models[test.model].PathLoss(test.distance, test.sample); math.Abs(got-test.want) > 1e-3 {
+			t.Errorf("%s: path loss is %.4f, want %.4f", test.name, got, test.want)
+		}
+	}
+}
+
+func TestPathLossGrowsWithTheDistance(t *testing.T) {
+	for name, model := range propagationModels(t) {
+		previous := model.PathLoss(0, 0)
+		for distance := 0.5; distance < 1e5; distance *= 1.1 {
+			loss := model.PathLoss(distance, 0)
+			if loss < previous {
+				t.Errorf("%s: the loss drops to %.4f at %.2f m from %.4f", name, loss, distance, previous)
+				break
+			}
+			previous = loss
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	config := DefaultPropagationConfig()
+	models := propagationModels(t)
+
+	for name, model := range models {
+		coverage := config.Range(model, DefaultTxPower, DefaultSensitivity)
+		budget := DefaultTxPower - DefaultSensitivity
+		meters := coverage * config.MetersPerUnit
+
+		if loss := model.PathLoss(meters, 0); math.Abs(loss-budget) > 1e-6 {
+			t.Errorf("%s: the loss at the range is %.6f, want the budget %.1f", name, loss, budget)
+		}
+		if model.PathLoss(meters*1.001, 0) <= budget {
+			t.Errorf("%s: the signal should be under the sensitivity past the range", name)
+		}
+	}
+
+	// 40.0542 + 30 log10(d) = 105 at the default log distance
+	if coverage := config.Range(models[PropagationLogDistance], DefaultTxPower, DefaultSensitivity); math.Abs(coverage-29.24) > 0.01 {
+		t.Error("the log distance range should be about 29.24 units, got", coverage)
+	}
+	if coverage := config.Range(models[PropagationFreeSpace], -50, DefaultSensitivity); coverage != 0 {
+		t.Error("a budget under the loss at a meter should have no range, got", coverage)
+	}
+}
+
+func TestErrorRateFromMargin(t *testing.T) {
+	if rate := ErrorRateFromMargin(0); math.Abs(rate-sensitivityErrorRate) > 1e-9 {
+		t.Error("the error rate at the sensitivity should be 10%, got", rate)
+	}
+	if rate := ErrorRateFromMargin(5); math.Abs(rate-1/(1+9*10.0)) > 1e-9 {
+		t.Error("the error rate 5 dB over the sensitivity is wrong, got", rate)
+	}
+
+	previous := ErrorRateFromMargin(-20)
+	for margin := -19.0; margin <= 40; margin++ {
+		rate := ErrorRateFromMargin(margin)
+		if rate >= previous || rate <= 0 || rate >= 1 {
+			t.Errorf("the error rate should drop with the margin, %v at %v dB after %v", rate, margin, previous)
+		}
+		previous = rate
+	}
+}
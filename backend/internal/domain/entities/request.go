@@ -1,11 +1,18 @@
 package entities
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// BroadcastDestination is the destination of messages sent to every device, or
+// to the members of the header when it has any
+const BroadcastDestination = "broadcast"
+
 type Requests struct {
 	Sent     map[uuid.UUID]*Request
 	Received map[uuid.UUID]*Request
@@ -25,6 +32,20 @@ type Header struct {
 	Destination string
 	Path        []Route
 	ContentType string
+	// Members are the multicast group of a broadcast message
+	Members []string
+	// Reliability and Priority are chosen by the routing policy of the message
+	Reliability string
+	Priority    string
+	// Forwarding is the forwarding mode of a user message, Target the device
+	// it is for and RoutingType the metric the relays route it with
+	Forwarding  string
+	Target      string
+	RoutingType string
+	// HopLimit is the amount of hops a hop-by-hop message can still take
+	HopLimit int
+	// Trace are the devices a user message went through
+	Trace []string
 }
 
 func NewRequest(topic, source, target string, path []Route, body interface{}) Request {
@@ -42,6 +63,40 @@ func NewRequest(topic, source, target string, path []Route, body interface{}) Re
 	}
 }
 
+// Size returns the bytes the request takes on the air, its header and body
+// encoded as JSON
+func (m *Request) Size() int {
+	header, _ := json.Marshal(m.Header)
+	body, err := json.Marshal(m.Body)
+	if err != nil {
+		body = []byte(fmt.Sprintf("%v", m.Body))
+	}
+	return len(header) + len(body)
+}
+
+// Relay returns the user message sender relays to target along path, with the
+// header of the message and sender added to its trace
+func (m *Request) Relay(sender, target string, path []Route) Request {
+	relayed := NewRequest("user-message", sender, target, path, m.Body)
+	relayed.Header.ContentType = m.Header.ContentType
+	relayed.Header.Reliability = m.Header.Reliability
+	relayed.Header.Priority = m.Header.Priority
+	relayed.Header.Forwarding = m.Header.Forwarding
+	relayed.Header.Target = m.Header.Target
+	relayed.Header.RoutingType = m.Header.RoutingType
+	relayed.Header.HopLimit = m.Header.HopLimit
+	relayed.Header.Trace = append(slices.Clone(m.Header.Trace), sender)
+	return relayed
+}
+
+// RelayHop returns the hop-by-hop message sender relays to next, using up one
+// hop
+func (m *Request) RelayHop(sender, next string) Request {
+	relayed := m.Relay(sender, next, nil)
+	relayed.Header.HopLimit--
+	return relayed
+}
+
 func (m *Request) Read() {
 	m.read = true
 }
@@ -4,3 +4,19 @@ type Route struct {
 	Source string
 	Target string
 }
+
+type RouteConstraints struct {
+	Avoid      []string
+	AvoidLinks []Route
+	Via        []string
+	MaxHops    int
+	MaxCost    float64
+}
+
+func (c RouteConstraints) IsZero() bool {
+	return len(c.Avoid) == 0 &&
+		len(c.AvoidLinks) == 0 &&
+		len(c.Via) == 0 &&
+		c.MaxHops == 0 &&
+		c.MaxCost == 0
+}
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+)
+
+type routeCacheKey struct {
+	Target string
+	Type   string
+}
+
+// CachedRoute is a path found from the routing table of a device at
+// TableVersion
+type CachedRoute struct {
+	Target       string
+	Type         string
+	Routes       []Route
+	TableVersion uint64
+}
+
+// RouteCacheStats tells how well the route cache of a device is doing
+type RouteCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries []CachedRoute
+}
+
+// RouteCache keeps the paths a device found, by target and routing type, and
+// the links known to be broken that the routing table still has. It is not
+// safe for concurrent use, the device guards it.
+type RouteCache struct {
+	entries map[routeCacheKey]CachedRoute
+	broken  map[Route]bool
+	hits    uint64
+	misses  uint64
+}
+
+// Get returns the path to target for the routing type if it was found with
+// the table at tableVersion. Paths found with an older table are dropped.
+func (c *RouteCache) Get(target, routingType string, tableVersion uint64) ([]Route, bool) {
+	key := routeCacheKey{target, routingType}
+	cached, exists := c.entries[key]
+	if exists && (cached.TableVersion != tableVersion || slices.ContainsFunc(cached.Routes, c.IsBroken)) {
+		delete(c.entries, key)
+		exists = false
+	}
+	if !exists {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	return slices.Clone(cached.Routes), true
+}
+
+// Put keeps the path to target for the routing type found with the table at
+// tableVersion, unless it uses a broken link
+func (c *RouteCache) Put(target, routingType string, tableVersion uint64, routes []Route) {
+	if slices.ContainsFunc(routes, c.IsBroken) {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[routeCacheKey]CachedRoute)
+	}
+	c.entries[routeCacheKey{target, routingType}] = CachedRoute{
+		Target:       target,
+		Type:         routingType,
+		Routes:       slices.Clone(routes),
+		TableVersion: tableVersion,
+	}
+}
+
+// Invalidate drops the paths that use a link broken tells is broken, and
+// returns how many were dropped
+func (c *RouteCache) Invalidate(broken func(link Route) bool) int {
+	dropped := 0
+	for key, cached := range c.entries {
+		if slices.ContainsFunc(cached.Routes, broken) {
+			delete(c.entries, key)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// Break marks the links as broken and drops the paths that use them, it
+// returns how many were dropped. The routing table takes a while to forget a
+// link, the paths found meanwhile should not use it again.
+func (c *RouteCache) Break(links []Route) int {
+	if len(links) == 0 {
+		return 0
+	}
+	if c.broken == nil {
+		c.broken = make(map[Route]bool)
+	}
+	for _, link := range links {
+		c.broken[link] = true
+	}
+	return c.Invalidate(c.IsBroken)
+}
+
+// Restore unmarks the links that came back
+func (c *RouteCache) Restore(links []Route) {
+	for _, link := range links {
+		delete(c.broken, link)
+	}
+}
+
+// IsBroken tells if link is marked as broken
+func (c *RouteCache) IsBroken(link Route) bool {
+	return c.broken[link]
+}
+
+// Stats returns the counters and the cached paths, sorted by target and type
+func (c *RouteCache) Stats() RouteCacheStats {
+	entries := make([]CachedRoute, 0, len(c.entries))
+	for _, cached := range c.entries {
+		cached.Routes = slices.Clone(cached.Routes)
+		entries = append(entries, cached)
+	}
+	slices.SortFunc(entries, func(a, b CachedRoute) int {
+		return cmp.Or(
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.Type, b.Type),
+		)
+	})
+
+	return RouteCacheStats{
+		Hits:    c.hits,
+		Misses:  c.misses,
+		Entries: entries,
+	}
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBrokenLinkIsNotRoutedAgain(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{Label: "A", RoutingTable: make(Routing)}
+	metric := Metric{Name: "distance", Combine: CombineAdditive}
+	labels := []string{"A", "B", "C", "D"}
+
+	table := make(Routing)
+	table.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+	table.Set("distance", "B", "C", NewOwnRouteEntry("B", 1, now))
+	table.Set("distance", "A", "D", NewOwnRouteEntry("A", 2, now))
+	table.Set("distance", "D", "C", NewOwnRouteEntry("D", 2, now))
+	device.AddRouting(table)
+	tableVersion := device.RoutingTableVersion()
+
+	shortest := func() []string {
+		t.Helper()
+		best, err := device.RoutingGraph(metric, labels).Shortest("A", "C")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return best.Path
+	}
+
+	overB := []Route{{Source: "A", Target: "B"}, {Source: "B", Target: "C"}}
+	if path := shortest(); !slices.Equal(path, []string{"A", "B", "C"}) {
+		t.Fatal("the path should go over B, got", path)
+	}
+	device.CacheRoute("C", "distance", tableVersion, overB)
+
+	if dropped := device.BreakLinks([]Route{{Source: "B", Target: "C"}, {Source: "C", Target: "B"}}); dropped != 1 {
+		t.Fatal("the path over the broken link should be dropped, got", dropped)
+	}
+	if _, hit := device.GetCachedRoute("C", "distance"); hit {
+		t.Error("the path over the broken link should not be cached")
+	}
+
+	// the table still has the link, the graph leaves it out
+	if _, known := device.CloneRoutingTable()["distance"]["B"]["C"]; !known {
+		t.Fatal("the table should still have the broken link")
+	}
+	if path := shortest(); !slices.Equal(path, []string{"A", "D", "C"}) {
+		t.Error("the path should go around the broken link, got", path)
+	}
+
+	// a path found before the link broke is not cached again
+	device.CacheRoute("C", "distance", tableVersion, overB)
+	if _, hit := device.GetCachedRoute("C", "distance"); hit {
+		t.Error("a path over the broken link should not be cached again")
+	}
+
+	device.RestoreLinks([]Route{{Source: "B", Target: "C"}, {Source: "C", Target: "B"}})
+	if path := shortest(); !slices.Equal(path, []string{"A", "B", "C"}) {
+		t.Error("the path should go over B once the link is back, got", path)
+	}
+	device.CacheRoute("C", "distance", tableVersion, overB)
+	if _, hit := device.GetCachedRoute("C", "distance"); !hit {
+		t.Error("the path should be cached once the link is back")
+	}
+}
@@ -1,4 +1,153 @@
 package entities
 
-// type -> source -> target -> weight
-type Routing map[string]map[string]map[string]float64
+import "time"
+
+// RouteEntryTTL is how long a link stays in the routing tables after its
+// source last announced it
+const RouteEntryTTL = 60 * time.Second
+
+// RouteEntry is a link known by a device
+type RouteEntry struct {
+	Weight float64
+	// LearnedFrom is the neighbour that sent the entry, the device itself for
+	// its own links
+	LearnedFrom string
+	LearnedAt   time.Time
+	// ExpiresAt is set by the source of the link when it announces it, relayed
+	// entries keep it so a link expires everywhere once it stops being
+	// announced
+	ExpiresAt time.Time
+	// HopCount is the amount of devices the entry was relayed by, 0 for the
+	// links of the device itself
+	HopCount int
+	// NextHop is the neighbour to forward to towards the source of the link,
+	// the device itself for its own links
+	NextHop string
+}
+
+// Expired tells if the entry should be purged at now
+func (e RouteEntry) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// NewOwnRouteEntry returns the entry of a link of device itself
+func NewOwnRouteEntry(device string, weight float64, now time.Time) RouteEntry {
+	return RouteEntry{
+		Weight:      weight,
+		LearnedFrom: device,
+		LearnedAt:   now,
+		ExpiresAt:   now.Add(RouteEntryTTL),
+		NextHop:     device,
+	}
+}
+
+// type -> source -> target -> entry
+type Routing map[string]map[string]map[string]RouteEntry
+
+// Set adds or replaces the entry of the link
+func (r Routing) Set(routingType, source, target string, entry RouteEntry) {
+	if r[routingType] == nil {
+		r[routingType] = make(map[string]map[string]RouteEntry)
+	}
+	if r[routingType][source] == nil {
+		r[routingType][source] = make(map[string]RouteEntry)
+	}
+	r[routingType][source][target] = entry
+}
+
+// Merge sets every entry of other in the table
+func (r Routing) Merge(other Routing) {
+	for routingType, sources := range other {
+		for source, targets := range sources {
+			for target, entry := range targets {
+				r.Set(routingType, source, target, entry)
+			}
+		}
+	}
+}
+
+// Len returns the amount of entries of the table
+func (r Routing) Len() int {
+	entries := 0
+	for _, sources := range r {
+		for _, targets := range sources {
+			entries += len(targets)
+		}
+	}
+	return entries
+}
+
+// Clone returns a deep copy of the table
+func (r Routing) Clone() Routing {
+	clone := make(Routing, len(r))
+	clone.Merge(r)
+	return clone
+}
+
+// Relayed returns a copy of the table as learned by a neighbour of sender
+func (r Routing) Relayed(sender string, now time.Time) Routing {
+	relayed := make(Routing, len(r))
+	for routingType, sources := range r {
+		for source, targets := range sources {
+			for target, entry := range targets {
+				entry.LearnedFrom = sender
+				entry.LearnedAt = now
+				entry.HopCount++
+				entry.NextHop = sender
+				relayed.Set(routingType, source, target, entry)
+			}
+		}
+	}
+	return relayed
+}
+
+// LinkKnowledge tells which devices have a link of a routing type in their
+// routing table, and with which weight
+type LinkKnowledge struct {
+	Route
+	Type string
+	// Weights has the weight of the link in the table of every device that
+	// knows it
+	Weights map[string]float64
+	// Missing are the devices whose table does not have the link
+	Missing []string
+	// Consistent is false when devices know the link with different weights
+	Consistent bool
+	// Live is false when the source has no connection to the target, so the
+	// devices that know the link know a stale one
+	Live bool
+}
+
+// HasIssues tells if the link is missing from a table, known with different
+// weights or stale
+func (k LinkKnowledge) HasIssues() bool {
+	return len(k.Missing) > 0 || !k.Consistent || !k.Live
+}
+
+// RoutingTableView is the network wide view of the routing tables of the
+// devices
+type RoutingTableView struct {
+	Devices []string
+	Links   []LinkKnowledge
+}
+
+// RoutingTableFilter selects part of the routing table view, empty fields
+// match everything
+type RoutingTableFilter struct {
+	// Device only considers the routing table of this device
+	Device string
+	Type   string
+	Source string
+	Target string
+	// Issues only keeps the links with missing, inconsistent or stale
+	// knowledge
+	Issues bool
+}
+
+// Matches tells if the link of routingType from source to target is selected
+// by the filter
+func (f RoutingTableFilter) Matches(routingType, source, target string) bool {
+	return (f.Type == "" || f.Type == routingType) &&
+		(f.Source == "" || f.Source == source) &&
+		(f.Target == "" || f.Target == target)
+}
@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxRoutingHistory is the amount of routing table versions a device keeps
+const maxRoutingHistory = 100
+
+// RoutingVersion is the routing table of a device from At until the next
+// version
+type RoutingVersion struct {
+	Version uint64
+	At      time.Time
+	Table   Routing
+}
+
+// RoutingHistory keeps the last versions of a routing table. A version is
+// only written when an entry is added, removed or routed differently, entries
+// refreshed with new timestamps do not count as a change.
+type RoutingHistory struct {
+	mu       sync.Mutex
+	versions []RoutingVersion
+	// sequence is the sequence of the latest table recorded
+	sequence uint64
+}
+
+// Record writes table as a new version if it differs from the latest one.
+// sequence numbers the changes of the table, the tables recorded after a
+// newer one are dropped. table is kept as is, it must be a copy the caller no
+// longer modifies.
+func (h *RoutingHistory) Record(sequence uint64, table Routing, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sequence <= h.sequence {
+		return
+	}
+	h.sequence = sequence
+
+	var latest RoutingVersion
+	if len(h.versions) > 0 {
+		latest = h.versions[len(h.versions)-1]
+		if DiffRouting(latest.Table, table).Empty() {
+			return
+		}
+	}
+
+	h.versions = append(h.versions, RoutingVersion{
+		Version: latest.Version + 1,
+		At:      now,
+		Table:   table,
+	})
+	if len(h.versions) > maxRoutingHistory {
+		h.versions = slices.Delete(h.versions, 0, len(h.versions)-maxRoutingHistory)
+	}
+}
+
+// Versions returns the versions kept, oldest first
+func (h *RoutingHistory) Versions() []RoutingVersion {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return slices.Clone(h.versions)
+}
+
+// Get returns the version numbered version if it is still kept
+func (h *RoutingHistory) Get(version uint64) (RoutingVersion, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i, found := slices.BinarySearchFunc(h.versions, version, func(v RoutingVersion, version uint64) int {
+		return cmp.Compare(v.Version, version)
+	})
+	if !found {
+		return RoutingVersion{}, false
+	}
+	return h.versions[i], true
+}
+
+// At returns the version that was current at t
+func (h *RoutingHistory) At(t time.Time) (RoutingVersion, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := len(h.versions) - 1; i >= 0; i-- {
+		if !h.versions[i].At.After(t) {
+			return h.versions[i], true
+		}
+	}
+	return RoutingVersion{}, false
+}
+
+// Latest returns the newest version, false if nothing was recorded yet
+func (h *RoutingHistory) Latest() (RoutingVersion, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.versions) == 0 {
+		return RoutingVersion{}, false
+	}
+	return h.versions[len(h.versions)-1], true
+}
+
+// RouteChange is an entry of a routing table that differs between two
+// versions, Before is nil for added entries and After for removed ones
+type RouteChange struct {
+	Type   string
+	Source string
+	Target string
+	Before *RouteEntry
+	After  *RouteEntry
+}
+
+// RoutingDiff has the entries added, removed and changed between two versions
+// of a routing table, sorted by type, source and target
+type RoutingDiff struct {
+	From    uint64
+	To      uint64
+	Added   []RouteChange
+	Removed []RouteChange
+	Changed []RouteChange
+}
+
+// Empty tells if both versions have the same routes
+func (d RoutingDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SameRoute tells if both entries route the same way, timestamps are ignored
+func (e RouteEntry) SameRoute(other RouteEntry) bool {
+	return e.Weight == other.Weight &&
+		e.LearnedFrom == other.LearnedFrom &&
+		e.HopCount == other.HopCount &&
+		e.NextHop == other.NextHop
+}
+
+// DiffRouting compares two routing tables, the version numbers of the diff are
+// left for the caller
+func DiffRouting(from, to Routing) RoutingDiff {
+	diff := RoutingDiff{
+		Added:   make([]RouteChange, 0),
+		Removed: make([]RouteChange, 0),
+		Changed: make([]RouteChange, 0),
+	}
+
+	for routingType, sources := range to {
+		for source, targets := range sources {
+			for target, after := range targets {
+				change := RouteChange{Type: routingType, Source: source, Target: target, After: &after}
+				before, known := from[routingType][source][target]
+				switch {
+				case !known:
+					diff.Added = append(diff.Added, change)
+				case !before.SameRoute(after):
+					change.Before = &before
+					diff.Changed = append(diff.Changed, change)
+				}
+			}
+		}
+	}
+
+	for routingType, sources := range from {
+		for source, targets := range sources {
+			for target, before := range targets {
+				if _, known := to[routingType][source][target]; !known {
+					diff.Removed = append(diff.Removed, RouteChange{Type: routingType, Source: source, Target: target, Before: &before})
+				}
+			}
+		}
+	}
+
+	for _, changes := range [][]RouteChange{diff.Added, diff.Removed, diff.Changed} {
+		slices.SortFunc(changes, func(a, b RouteChange) int {
+			return cmp.Or(
+				cmp.Compare(a.Type, b.Type),
+				cmp.Compare(a.Source, b.Source),
+				cmp.Compare(a.Target, b.Target),
+			)
+		})
+	}
+
+	return diff
+}
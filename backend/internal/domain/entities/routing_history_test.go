@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoutingHistoryRecord(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var history RoutingHistory
+
+	first := make(Routing)
+	first.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+	history.Record(1, first, now)
+
+	refreshed := make(Routing)
+	refreshed.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now.Add(time.Second)))
+	history.Record(2, refreshed, now.Add(time.Second))
+	if versions := history.Versions(); len(versions) != 1 {
+		t.Fatal("refreshed entries should not write a version, got", len(versions))
+	}
+
+	changed := make(Routing)
+	changed.Set("distance", "A", "B", NewOwnRouteEntry("A", 2, now))
+	history.Record(4, changed, now.Add(2*time.Second))
+
+	stale := make(Routing)
+	history.Record(3, stale, now.Add(3*time.Second))
+
+	latest, found := history.Latest()
+	if !found || latest.Version != 2 || latest.Table["distance"]["A"]["B"].Weight != 2 {
+		t.Error("tables recorded after a newer one should be dropped", latest)
+	}
+	if version, found := history.At(now.Add(time.Second)); !found || version.Version != 1 {
+		t.Error("wrong version current at a time", version)
+	}
+	if _, found := history.At(now.Add(-time.Second)); found {
+		t.Error("no version should be current before the first one")
+	}
+	if version, found := history.Get(2); !found || version.At != now.Add(2*time.Second) {
+		t.Error("wrong version by number", version)
+	}
+}
+
+func TestRoutingHistoryLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var history RoutingHistory
+
+	for i := range maxRoutingHistory + 5 {
+		table := make(Routing)
+		table.Set("distance", "A", "B", NewOwnRouteEntry("A", float64(i), now))
+		history.Record(uint64(i+1), table, now)
+	}
+
+	versions := history.Versions()
+	if len(versions) != maxRoutingHistory || versions[0].Version != 6 {
+		t.Error("only the newest versions should be kept", len(versions), versions[0].Version)
+	}
+	if _, found := history.Get(5); found {
+		t.Error("dropped versions should not be found")
+	}
+}
+
+func TestDiffRouting(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	from := make(Routing)
+	from.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+	from.Set("distance", "A", "C", NewOwnRouteEntry("A", 1, now))
+	from.Set("distance", "B", "C", NewOwnRouteEntry("B", 1, now))
+
+	to := make(Routing)
+	to.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now.Add(time.Second)))
+	to.Set("distance", "A", "C", NewOwnRouteEntry("A", 3, now))
+	to.Set("latency", "A", "B", NewOwnRouteEntry("A", 1, now))
+	to.Set("distance", "A", "D", NewOwnRouteEntry("A", 1, now))
+
+	diff := DiffRouting(from, to)
+
+	if len(diff.Added) != 2 || diff.Added[0].Type != "distance" || diff.Added[0].Target != "D" || diff.Added[1].Type != "latency" {
+		t.Error("wrong added entries", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Source != "B" || diff.Removed[0].After != nil {
+		t.Error("wrong removed entries", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Target != "C" || diff.Changed[0].Before.Weight != 1 || diff.Changed[0].After.Weight != 3 {
+		t.Error("wrong changed entries", diff.Changed)
+	}
+	if !DiffRouting(from, from.Clone()).Empty() {
+		t.Error("a table should not differ from its clone")
+	}
+}
+
+func TestDeviceRoutingHistory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{Label: "A", RoutingTable: make(Routing)}
+
+	table := make(Routing)
+	table.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+	device.AddRouting(table)
+	device.AddRouting(table)
+	device.RemoveFromTableRoutesWith("B")
+
+	versions := device.GetRoutingHistory()
+	if len(versions) != 2 || device.RoutingTableVersion() != 2 {
+		t.Fatal("every change of the table should write one version, got", len(versions))
+	}
+	if versions[1].Table["distance"]["A"] != nil && len(versions[1].Table["distance"]["A"]) != 0 {
+		t.Error("the removed link should not be in the latest version", versions[1].Table)
+	}
+
+	device.AddRouting(table)
+	if versions[0].Table["distance"]["A"]["B"].Weight != 1 || len(versions[1].Table["distance"]["A"]) != 0 {
+		t.Error("recorded versions should not change with the table")
+	}
+}
@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRouteEntryExpired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := NewOwnRouteEntry("A", 1, now)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"fresh entry", now, false},
+		{"just before the ttl", now.Add(RouteEntryTTL - time.Nanosecond), false},
+		{"at the ttl", now.Add(RouteEntryTTL), true},
+		{"past the ttl", now.Add(2 * RouteEntryTTL), true},
+	}
+	for _, test := range tests {
+		if got := entry.Expired(test.at); got != test.want {
+			t.Errorf("%s: expired is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestPurgeExpiredRoutes(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	device := &Device{Label: "A", RoutingTable: make(Routing)}
+
+	table := make(Routing)
+	table.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+	table.Set("distance", "C", "D", NewOwnRouteEntry("C", 1, now.Add(-RouteEntryTTL)))
+	device.AddRouting(table)
+
+	if purged := device.PurgeExpiredRoutes(now); purged != 1 {
+		t.Fatal("one entry should be purged, got", purged)
+	}
+	routingTable := device.CloneRoutingTable()
+	if _, known := routingTable["distance"]["A"]["B"]; !known {
+		t.Error("the fresh entry should be kept")
+	}
+	if _, known := routingTable["distance"]["C"]; known {
+		t.Error("sources left with no targets should be removed")
+	}
+}
+
+func TestAddRoutingPrecedence(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	known := RouteEntry{Weight: 1, LearnedFrom: "B", ExpiresAt: now, HopCount: 2, NextHop: "B"}
+
+	tests := []struct {
+		name     string
+		entry    RouteEntry
+		replaced bool
+	}{
+		{"announced later", RouteEntry{Weight: 2, LearnedFrom: "C", ExpiresAt: now.Add(time.Second), HopCount: 5, NextHop: "C"}, true},
+		{"announced earlier", RouteEntry{Weight: 2, LearnedFrom: "C", ExpiresAt: now.Add(-time.Second), HopCount: 1, NextHop: "C"}, false},
+		{"same announce with fewer hops", RouteEntry{Weight: 2, LearnedFrom: "C", ExpiresAt: now, HopCount: 1, NextHop: "C"}, true},
+		{"same announce with as many hops", RouteEntry{Weight: 2, LearnedFrom: "C", ExpiresAt: now, HopCount: 2, NextHop: "C"}, false},
+		{"same announce with more hops", RouteEntry{Weight: 2, LearnedFrom: "C", ExpiresAt: now, HopCount: 3, NextHop: "C"}, false},
+	}
+	for _, test := range tests {
+		device := &Device{Label: "A", RoutingTable: make(Routing)}
+		device.RoutingTable.Set("distance", "D", "E", known)
+
+		update := make(Routing)
+		update.Set("distance", "D", "E", test.entry)
+		device.AddRouting(update)
+
+		want := known
+		if test.replaced {
+			want = test.entry
+		}
+		if got := device.CloneRoutingTable()["distance"]["D"]["E"]; got != want {
+			t.Errorf("%s: entry is %+v, want %+v", test.name, got, want)
+		}
+	}
+}
+
+func TestRelayed(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	table := make(Routing)
+	table.Set("distance", "A", "B", NewOwnRouteEntry("A", 1, now))
+
+	later := now.Add(time.Second)
+	got := table.Relayed("A", later)["distance"]["A"]["B"]
+	want := RouteEntry{Weight: 1, LearnedFrom: "A", LearnedAt: later, ExpiresAt: now.Add(RouteEntryTTL), HopCount: 1, NextHop: "A"}
+	if got != want {
+		t.Errorf("relayed entry is %+v, want %+v", got, want)
+	}
+	if entry := table["distance"]["A"]["B"]; entry.HopCount != 0 {
+		t.Error("relaying should not change the table", entry)
+	}
+}
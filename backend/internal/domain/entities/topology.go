@@ -0,0 +1,88 @@
+package entities
+
+// DistanceMatrix has the best path between every pair of devices, its value in
+// the unit of the routing type it was computed for and its first hop. Both
+// are nil when there is no path.
+type DistanceMatrix struct {
+	Devices   []string
+	Distances [][]*float64
+	NextHops  [][]*string
+	// Version is the version of the shared topology graph the matrix was
+	// computed on
+	Version uint64
+}
+
+// TopologyAnalysis describes the structure of the neighbour graph, weights are
+// in the unit of the routing type it was computed for
+type TopologyAnalysis struct {
+	Partitioned                 bool
+	StronglyConnected           bool
+	StronglyConnectedComponents [][]string
+	WeaklyConnectedComponents   [][]string
+	ArticulationPoints          []string
+	Bridges                     []Route
+	// Eccentricity only has the devices that reach every other device
+	Eccentricity map[string]float64
+	// Radius and Diameter are nil when the graph is not strongly connected
+	Radius   *float64
+	Diameter *float64
+	// Version is the version of the shared topology graph analysed
+	Version uint64
+}
+
+// DeviceCentrality tells how central a device is in the neighbour graph,
+// closeness is in the inverse unit of the routing type it was computed for
+type DeviceCentrality struct {
+	Label       string
+	Betweenness float64
+	Closeness   float64
+	Degree      float64
+}
+
+// Backbone is the tree of links broadcast and multicast messages are forwarded
+// on, weight is in the unit of the routing type it was computed for
+type Backbone struct {
+	Algorithm string
+	// Terminals are the devices a Steiner backbone joins, empty for a spanning
+	// tree
+	Terminals []string
+	Links     []Route
+	Weight    float64
+	// Version is the version of the shared topology graph the backbone was
+	// computed on, 0 when it was computed on a routing table
+	Version uint64
+}
+
+// Neighbours returns the devices joined to label by a backbone link
+func (b Backbone) Neighbours(label string) []string {
+	neighbours := make([]string, 0)
+	for _, link := range b.Links {
+		switch label {
+		case link.Source:
+			neighbours = append(neighbours, link.Target)
+		case link.Target:
+			neighbours = append(neighbours, link.Source)
+		}
+	}
+	return neighbours
+}
+
+// MaxFlow is the traffic that can flow from the sources to the sinks over the
+// link capacities, in Mbps
+type MaxFlow struct {
+	Sources []string
+	Sinks   []string
+	Value   float64
+	Flows   []LinkFlow
+	// MinCut are the saturated links that limit the flow
+	MinCut []LinkFlow
+	// Version is the version of the shared capacity graph
+	Version uint64
+}
+
+// LinkFlow is the traffic sent over a link and the capacity of the link
+type LinkFlow struct {
+	Route
+	Flow     float64
+	Capacity float64
+}
@@ -15,10 +15,16 @@ type ApiControllerInterface interface {
 	DevicesControllerInterface
 	EnvironmentControllerInterface
 	ChartControllerInterface
+	TopologyControllerInterface
 }
 
 type RoutingsControllerInterface interface {
 	GetTable(c *gin.Context)
+	GetConvergence(c *gin.Context)
+	GetDeviceTable(c *gin.Context)
+	GetDeviceTableHistory(c *gin.Context)
+	GetDeviceTableDiff(c *gin.Context)
+	GetRouteCache(c *gin.Context)
 }
 
 type DevicesControllerInterface interface {
@@ -33,13 +39,41 @@ type DevicesControllerInterface interface {
 
 type EnvironmentControllerInterface interface {
 	GetEnvironment(c *gin.Context)
+	GetMetrics(c *gin.Context)
+	GetPolicies(c *gin.Context)
+	InsertPolicy(c *gin.Context)
+	UpdatePolicy(c *gin.Context)
+	DeletePolicy(c *gin.Context)
+	GetMultipath(c *gin.Context)
+	SetMultipathConfig(c *gin.Context)
+	ResetMultipathLoad(c *gin.Context)
+	GetForwarding(c *gin.Context)
+	SetForwardingMode(c *gin.Context)
+	GetPropagation(c *gin.Context)
+	SetPropagation(c *gin.Context)
+	GetMedium(c *gin.Context)
+	SetMediumConfig(c *gin.Context)
+	ResetMediumStats(c *gin.Context)
+	GetMAC(c *gin.Context)
+	SetMACConfig(c *gin.Context)
+	ResetMACStats(c *gin.Context)
 }
 
 type ChartControllerInterface interface {
 	GetChart(c *gin.Context)
+	GetChartLinks(c *gin.Context)
 	SetDeviceInChart(c *gin.Context)
 }
 
+type TopologyControllerInterface interface {
+	GetDistanceMatrix(c *gin.Context)
+	GetTopologyAnalysis(c *gin.Context)
+	GetCentrality(c *gin.Context)
+	GetTopologyExport(c *gin.Context)
+	GetBackbone(c *gin.Context)
+	GetMaxFlow(c *gin.Context)
+}
+
 func NewApiController(apiServices services.ApiServices) ApiControllerInterface {
 	return &apiControllerInterface{
 		services: apiServices,
@@ -31,6 +31,28 @@ func (sc *apiControllerInterface) GetChart(c *gin.Context) {
 	c.JSON(http.StatusOK, model.ToChartResponse(chart))
 }
 
+func (sc *apiControllerInterface) GetChartLinks(c *gin.Context) {
+	logger.Info("Init GetChartLinks controller",
+		zap.String("journey", "GetChartLinks"),
+	)
+
+	links, err := sc.services.Environment.GetChartLinks(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get chart links",
+			err,
+			zap.String("journey", "GetChartLinks"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToChartLinksResponse(links))
+}
+
 func (sc *apiControllerInterface) SetDeviceInChart(c *gin.Context) {
 	logger.Info("Init SetDeviceInChart controller",
 		zap.String("journey", "SetDeviceInChart"),
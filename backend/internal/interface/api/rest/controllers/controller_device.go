@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -128,11 +127,25 @@ func (sc *apiControllerInterface) GetRoute(c *gin.Context) {
 	source := c.Param("source")
 	target := c.Param("target")
 
-	routingType := c.Query("type")
+	var query model.RouteQuery
+	err := c.ShouldBindQuery(&query)
+	if err == nil {
+		err = query.Validate()
+	}
+	if err != nil {
+		logger.Error("Error to bind route query",
+			err,
+			zap.String("journey", "GetRoute"),
+		)
 
-	fmt.Println(routingType)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
 
-	routes, err := sc.services.Device.GetRoute(c.Request.Context(), source, target, routingType)
+	routes, err := sc.services.Device.GetRoute(c.Request.Context(), source, target, query.Type, query.ToDomain())
 	if err != nil {
 		logger.Error("Error to get route",
 			err,
@@ -30,3 +30,25 @@ func (sc *apiControllerInterface) GetEnvironment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, model.ToEnvironmentResponse(environment))
 }
+
+func (sc *apiControllerInterface) GetMetrics(c *gin.Context) {
+	logger.Info("Init GetMetrics controller",
+		zap.String("journey", "GetMetrics"),
+	)
+
+	metrics, err := sc.services.Environment.GetMetrics(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get metrics",
+			err,
+			zap.String("journey", "GetMetrics"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToMetricsResponse(metrics))
+}
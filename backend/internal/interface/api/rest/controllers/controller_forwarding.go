@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetForwarding(c *gin.Context) {
+	logger.Info("Init GetForwarding controller",
+		zap.String("journey", "GetForwarding"),
+	)
+
+	mode, stats, err := sc.services.Environment.GetForwarding(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get forwarding",
+			err,
+			zap.String("journey", "GetForwarding"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToForwardingResponse(mode, stats))
+}
+
+func (sc *apiControllerInterface) SetForwardingMode(c *gin.Context) {
+	logger.Info("Init SetForwardingMode controller",
+		zap.String("journey", "SetForwardingMode"),
+	)
+
+	var forwarding model.ForwardingRequest
+	if err := c.ShouldBindJSON(&forwarding); err != nil {
+		logger.Error("Error to bind forwarding",
+			err,
+			zap.String("journey", "SetForwardingMode"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.SetForwardingMode(c.Request.Context(), forwarding.Mode); err != nil {
+		logger.Error("Error to set forwarding mode",
+			err,
+			zap.String("journey", "SetForwardingMode"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Forwarding mode updated",
+	})
+}
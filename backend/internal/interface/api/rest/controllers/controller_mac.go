@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetMAC(c *gin.Context) {
+	logger.Info("Init GetMAC controller",
+		zap.String("journey", "GetMAC"),
+	)
+
+	config, stats, err := sc.services.Environment.GetMAC(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get mac",
+			err,
+			zap.String("journey", "GetMAC"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToMACResponse(config, stats))
+}
+
+func (sc *apiControllerInterface) SetMACConfig(c *gin.Context) {
+	logger.Info("Init SetMACConfig controller",
+		zap.String("journey", "SetMACConfig"),
+	)
+
+	var mac model.MACRequest
+	if err := c.ShouldBindJSON(&mac); err != nil {
+		logger.Error("Error to bind mac",
+			err,
+			zap.String("journey", "SetMACConfig"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.SetMACConfig(c.Request.Context(), mac.ToDomain()); err != nil {
+		logger.Error("Error to set mac config",
+			err,
+			zap.String("journey", "SetMACConfig"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "MAC config updated",
+	})
+}
+
+func (sc *apiControllerInterface) ResetMACStats(c *gin.Context) {
+	logger.Info("Init ResetMACStats controller",
+		zap.String("journey", "ResetMACStats"),
+	)
+
+	if err := sc.services.Environment.ResetMACStats(c.Request.Context()); err != nil {
+		logger.Error("Error to reset mac stats",
+			err,
+			zap.String("journey", "ResetMACStats"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "MAC stats reset",
+	})
+}
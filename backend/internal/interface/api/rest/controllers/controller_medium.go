@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetMedium(c *gin.Context) {
+	logger.Info("Init GetMedium controller",
+		zap.String("journey", "GetMedium"),
+	)
+
+	config, stats, collisions, err := sc.services.Environment.GetMedium(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get medium",
+			err,
+			zap.String("journey", "GetMedium"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToMediumResponse(config, stats, collisions))
+}
+
+func (sc *apiControllerInterface) SetMediumConfig(c *gin.Context) {
+	logger.Info("Init SetMediumConfig controller",
+		zap.String("journey", "SetMediumConfig"),
+	)
+
+	var medium model.MediumRequest
+	if err := c.ShouldBindJSON(&medium); err != nil {
+		logger.Error("Error to bind medium",
+			err,
+			zap.String("journey", "SetMediumConfig"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.SetMediumConfig(c.Request.Context(), medium.ToDomain()); err != nil {
+		logger.Error("Error to set medium config",
+			err,
+			zap.String("journey", "SetMediumConfig"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Medium config updated",
+	})
+}
+
+func (sc *apiControllerInterface) ResetMediumStats(c *gin.Context) {
+	logger.Info("Init ResetMediumStats controller",
+		zap.String("journey", "ResetMediumStats"),
+	)
+
+	if err := sc.services.Environment.ResetMediumStats(c.Request.Context()); err != nil {
+		logger.Error("Error to reset medium stats",
+			err,
+			zap.String("journey", "ResetMediumStats"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Medium stats reset",
+	})
+}
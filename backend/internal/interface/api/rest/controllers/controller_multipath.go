@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetMultipath(c *gin.Context) {
+	logger.Info("Init GetMultipath controller",
+		zap.String("journey", "GetMultipath"),
+	)
+
+	config, load, err := sc.services.Environment.GetMultipath(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get multipath",
+			err,
+			zap.String("journey", "GetMultipath"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToMultipathResponse(config, load))
+}
+
+func (sc *apiControllerInterface) SetMultipathConfig(c *gin.Context) {
+	logger.Info("Init SetMultipathConfig controller",
+		zap.String("journey", "SetMultipathConfig"),
+	)
+
+	var config model.MultipathConfigRequest
+	if err := c.ShouldBindJSON(&config); err != nil {
+		logger.Error("Error to bind multipath config",
+			err,
+			zap.String("journey", "SetMultipathConfig"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.SetMultipathConfig(c.Request.Context(), config.ToDomain()); err != nil {
+		logger.Error("Error to set multipath config",
+			err,
+			zap.String("journey", "SetMultipathConfig"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Multipath config updated",
+	})
+}
+
+func (sc *apiControllerInterface) ResetMultipathLoad(c *gin.Context) {
+	logger.Info("Init ResetMultipathLoad controller",
+		zap.String("journey", "ResetMultipathLoad"),
+	)
+
+	if err := sc.services.Environment.ResetMultipathLoad(c.Request.Context()); err != nil {
+		logger.Error("Error to reset multipath load",
+			err,
+			zap.String("journey", "ResetMultipathLoad"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Multipath load reset",
+	})
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetPolicies(c *gin.Context) {
+	logger.Info("Init GetPolicies controller",
+		zap.String("journey", "GetPolicies"),
+	)
+
+	policies, err := sc.services.Environment.GetPolicies(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get policies",
+			err,
+			zap.String("journey", "GetPolicies"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingPoliciesResponse(policies))
+}
+
+func (sc *apiControllerInterface) InsertPolicy(c *gin.Context) {
+	logger.Info("Init InsertPolicy controller",
+		zap.String("journey", "InsertPolicy"),
+	)
+
+	var policy model.RoutingPolicyRequest
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		logger.Error("Error to bind policy",
+			err,
+			zap.String("journey", "InsertPolicy"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	policyCreated, err := sc.services.Environment.InsertPolicy(c.Request.Context(), policy.ToDomain(uuid.Nil))
+	if err != nil {
+		logger.Error("Error to insert policy",
+			err,
+			zap.String("journey", "InsertPolicy"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.ToRoutingPolicyResponse(policyCreated))
+}
+
+func (sc *apiControllerInterface) UpdatePolicy(c *gin.Context) {
+	logger.Info("Init UpdatePolicy controller",
+		zap.String("journey", "UpdatePolicy"),
+	)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logger.Error("Error to parse policy id",
+			err,
+			zap.String("journey", "UpdatePolicy"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	var policy model.RoutingPolicyRequest
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		logger.Error("Error to bind policy",
+			err,
+			zap.String("journey", "UpdatePolicy"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	policyUpdated, err := sc.services.Environment.UpdatePolicy(c.Request.Context(), policy.ToDomain(id))
+	if err != nil {
+		logger.Error("Error to update policy",
+			err,
+			zap.String("journey", "UpdatePolicy"),
+			zap.String("id", id.String()),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingPolicyResponse(policyUpdated))
+}
+
+func (sc *apiControllerInterface) DeletePolicy(c *gin.Context) {
+	logger.Info("Init DeletePolicy controller",
+		zap.String("journey", "DeletePolicy"),
+	)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		logger.Error("Error to parse policy id",
+			err,
+			zap.String("journey", "DeletePolicy"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.DeletePolicy(c.Request.Context(), id); err != nil {
+		logger.Error("Error to delete policy",
+			err,
+			zap.String("journey", "DeletePolicy"),
+			zap.String("id", id.String()),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Policy deleted",
+	})
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetPropagation(c *gin.Context) {
+	logger.Info("Init GetPropagation controller",
+		zap.String("journey", "GetPropagation"),
+	)
+
+	config, links, err := sc.services.Environment.GetPropagation(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get propagation",
+			err,
+			zap.String("journey", "GetPropagation"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToPropagationResponse(config, links))
+}
+
+func (sc *apiControllerInterface) SetPropagation(c *gin.Context) {
+	logger.Info("Init SetPropagation controller",
+		zap.String("journey", "SetPropagation"),
+	)
+
+	var propagation model.PropagationRequest
+	if err := c.ShouldBindJSON(&propagation); err != nil {
+		logger.Error("Error to bind propagation",
+			err,
+			zap.String("journey", "SetPropagation"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	if err := sc.services.Environment.SetPropagation(c.Request.Context(), propagation.ToDomain()); err != nil {
+		logger.Error("Error to set propagation",
+			err,
+			zap.String("journey", "SetPropagation"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success", "message": "Propagation model updated",
+	})
+}
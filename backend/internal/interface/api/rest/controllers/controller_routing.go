@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
 	"github.com/luuisavelino/network-interface/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -13,7 +14,21 @@ func (sc *apiControllerInterface) GetTable(c *gin.Context) {
 		zap.String("journey", "GetTable"),
 	)
 
-	tableList, err := sc.services.RoutingTable.GetTable(c.Request.Context())
+	var query model.RoutingTableQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Error to bind routing table query",
+			err,
+			zap.String("journey", "GetTable"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	view, err := sc.services.RoutingTable.GetTable(c.Request.Context(), query.ToDomain())
 	if err != nil {
 		logger.Error("Error to list tables",
 			err,
@@ -21,11 +36,157 @@ func (sc *apiControllerInterface) GetTable(c *gin.Context) {
 		)
 
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error", "message": "Error to list tables",
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingTableViewResponse(view))
+}
+
+func (sc *apiControllerInterface) GetConvergence(c *gin.Context) {
+	logger.Info("Init GetConvergence controller",
+		zap.String("journey", "GetConvergence"),
+	)
+
+	status, err := sc.services.RoutingTable.GetConvergence(c.Request.Context())
+	if err != nil {
+		logger.Error("Error to get convergence",
+			err,
+			zap.String("journey", "GetConvergence"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToConvergenceResponse(status))
+}
+
+func (sc *apiControllerInterface) GetDeviceTable(c *gin.Context) {
+	logger.Info("Init GetDeviceTable controller",
+		zap.String("journey", "GetDeviceTable"),
+	)
+
+	deviceLabel := c.Param("label")
+
+	var query model.DeviceRoutingTableQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Error to bind device routing table query",
+			err,
+			zap.String("journey", "GetDeviceTable"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	routingVersion, err := sc.services.RoutingTable.GetDeviceTable(c.Request.Context(), deviceLabel, query.At)
+	if err != nil {
+		logger.Error("Error to get device routing table",
+			err,
+			zap.String("journey", "GetDeviceTable"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingVersionResponse(deviceLabel, routingVersion))
+}
+
+func (sc *apiControllerInterface) GetDeviceTableHistory(c *gin.Context) {
+	logger.Info("Init GetDeviceTableHistory controller",
+		zap.String("journey", "GetDeviceTableHistory"),
+	)
+
+	deviceLabel := c.Param("label")
+
+	versions, err := sc.services.RoutingTable.GetDeviceHistory(c.Request.Context(), deviceLabel)
+	if err != nil {
+		logger.Error("Error to get device routing table history",
+			err,
+			zap.String("journey", "GetDeviceTableHistory"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingHistoryResponse(deviceLabel, versions))
+}
+
+func (sc *apiControllerInterface) GetDeviceTableDiff(c *gin.Context) {
+	logger.Info("Init GetDeviceTableDiff controller",
+		zap.String("journey", "GetDeviceTableDiff"),
+	)
+
+	deviceLabel := c.Param("label")
+
+	var query model.RoutingDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Error to bind routing table diff query",
+			err,
+			zap.String("journey", "GetDeviceTableDiff"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	diff, err := sc.services.RoutingTable.GetDeviceTableDiff(c.Request.Context(), deviceLabel, query.From, query.To)
+	if err != nil {
+		logger.Error("Error to diff device routing table",
+			err,
+			zap.String("journey", "GetDeviceTableDiff"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToRoutingDiffResponse(deviceLabel, diff))
+}
+
+func (sc *apiControllerInterface) GetRouteCache(c *gin.Context) {
+	logger.Info("Init GetRouteCache controller",
+		zap.String("journey", "GetRouteCache"),
+	)
+
+	deviceLabel := c.Param("label")
+
+	stats, err := sc.services.RoutingTable.GetRouteCache(c.Request.Context(), deviceLabel)
+	if err != nil {
+		logger.Error("Error to get route cache",
+			err,
+			zap.String("journey", "GetRouteCache"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
 		})
 
 		return
 	}
 
-	c.JSON(http.StatusOK, tableList)
+	c.JSON(http.StatusOK, model.ToRouteCacheResponse(deviceLabel, stats))
 }
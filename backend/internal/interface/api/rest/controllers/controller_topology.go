@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luuisavelino/network-interface/internal/application/services"
+	"github.com/luuisavelino/network-interface/internal/interface/api/rest/model"
+	"github.com/luuisavelino/network-interface/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (sc *apiControllerInterface) GetDistanceMatrix(c *gin.Context) {
+	logger.Info("Init GetDistanceMatrix controller",
+		zap.String("journey", "GetDistanceMatrix"),
+	)
+
+	routingType := c.DefaultQuery("type", "distance")
+
+	matrix, err := sc.services.Topology.GetDistanceMatrix(c.Request.Context(), routingType)
+	if err != nil {
+		logger.Error("Error to get distance matrix",
+			err,
+			zap.String("journey", "GetDistanceMatrix"),
+		)
+
+		c.JSON(topologyErrorStatus(err), gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToDistanceMatrixResponse(routingType, matrix))
+}
+
+func (sc *apiControllerInterface) GetTopologyAnalysis(c *gin.Context) {
+	logger.Info("Init GetTopologyAnalysis controller",
+		zap.String("journey", "GetTopologyAnalysis"),
+	)
+
+	routingType := c.DefaultQuery("type", "distance")
+
+	analysis, err := sc.services.Topology.GetTopologyAnalysis(c.Request.Context(), routingType)
+	if err != nil {
+		logger.Error("Error to get topology analysis",
+			err,
+			zap.String("journey", "GetTopologyAnalysis"),
+		)
+
+		c.JSON(topologyErrorStatus(err), gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToTopologyAnalysisResponse(routingType, analysis))
+}
+
+func (sc *apiControllerInterface) GetCentrality(c *gin.Context) {
+	logger.Info("Init GetCentrality controller",
+		zap.String("journey", "GetCentrality"),
+	)
+
+	routingType := c.DefaultQuery("type", "distance")
+
+	ranking, version, err := sc.services.Topology.GetCentrality(c.Request.Context(), routingType)
+	if err != nil {
+		logger.Error("Error to get centrality",
+			err,
+			zap.String("journey", "GetCentrality"),
+		)
+
+		c.JSON(topologyErrorStatus(err), gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToCentralityResponse(routingType, version, ranking))
+}
+
+func (sc *apiControllerInterface) GetTopologyExport(c *gin.Context) {
+	logger.Info("Init GetTopologyExport controller",
+		zap.String("journey", "GetTopologyExport"),
+	)
+
+	routingType := c.DefaultQuery("type", "distance")
+	format := c.DefaultQuery("format", "json")
+
+	contentType, ok := model.ExportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": "unknown export format: " + format,
+		})
+
+		return
+	}
+
+	var export bytes.Buffer
+	version, err := sc.services.Topology.ExportTopology(c.Request.Context(), routingType, format, &export)
+	if err != nil {
+		logger.Error("Error to export topology",
+			err,
+			zap.String("journey", "GetTopologyExport"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.Header(model.TopologyVersionHeader, strconv.FormatUint(version, 10))
+	c.Data(http.StatusOK, contentType, export.Bytes())
+}
+
+func (sc *apiControllerInterface) GetBackbone(c *gin.Context) {
+	logger.Info("Init GetBackbone controller",
+		zap.String("journey", "GetBackbone"),
+	)
+
+	var query model.BackboneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Error to bind backbone query",
+			err,
+			zap.String("journey", "GetBackbone"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	backbone, err := sc.services.Topology.GetBackbone(c.Request.Context(), query.Type, query.Algorithm, query.GetTerminals())
+	if err != nil {
+		logger.Error("Error to get backbone",
+			err,
+			zap.String("journey", "GetBackbone"),
+		)
+
+		c.JSON(topologyErrorStatus(err), gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToBackboneResponse(query.Type, backbone))
+}
+
+func (sc *apiControllerInterface) GetMaxFlow(c *gin.Context) {
+	logger.Info("Init GetMaxFlow controller",
+		zap.String("journey", "GetMaxFlow"),
+	)
+
+	var query model.MaxFlowQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("Error to bind max flow query",
+			err,
+			zap.String("journey", "GetMaxFlow"),
+		)
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	maxFlow, err := sc.services.Topology.GetMaxFlow(c.Request.Context(), query.GetSources(), query.GetSinks())
+	if err != nil {
+		logger.Error("Error to get max flow",
+			err,
+			zap.String("journey", "GetMaxFlow"),
+		)
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status": "error", "message": err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, model.ToMaxFlowResponse(maxFlow))
+}
+
+// topologyErrorStatus returns the status of a failed topology query, the
+// queries that sum the links of a bottleneck metric are bad requests
+func topologyErrorStatus(err error) int {
+	if errors.Is(err, services.ErrBottleneckMetric) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
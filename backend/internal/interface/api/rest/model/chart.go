@@ -33,3 +33,41 @@ func (r CoverageArea) ToDomain() entities.CoverageArea {
 		R: r.R,
 	}
 }
+
+// ChartLinkResponse is a link between two devices, when it is not
+// bidirectional only source reaches target
+type ChartLinkResponse struct {
+	Source        string            `json:"source"`
+	Target        string            `json:"target"`
+	Bidirectional bool              `json:"bidirectional"`
+	Forward       LinkStateResponse `json:"forward"`
+	Reverse       LinkStateResponse `json:"reverse"`
+}
+
+type LinkStateResponse struct {
+	InRange   bool    `json:"in_range"`
+	RSSI      float64 `json:"rssi"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+func ToChartLinksResponse(links []entities.DirectionalLink) []ChartLinkResponse {
+	linksResponse := make([]ChartLinkResponse, 0, len(links))
+	for _, link := range links {
+		linksResponse = append(linksResponse, ChartLinkResponse{
+			Source:        link.Source,
+			Target:        link.Target,
+			Bidirectional: link.Bidirectional,
+			Forward:       toLinkStateResponse(link.Forward),
+			Reverse:       toLinkStateResponse(link.Reverse),
+		})
+	}
+	return linksResponse
+}
+
+func toLinkStateResponse(link entities.RadioLink) LinkStateResponse {
+	return LinkStateResponse{
+		InRange:   link.InRange,
+		RSSI:      link.RSSI,
+		ErrorRate: link.ErrorRate,
+	}
+}
@@ -1,18 +1,34 @@
 package model
 
 import (
+	"cmp"
+	"slices"
+	"time"
+
 	"github.com/luuisavelino/network-interface/internal/domain/entities"
 )
 
 type DeviceRequest struct {
 	Label        string `json:"label" binding:"required"`
 	Power        int    `json:"power" binding:"required"`
+	// TxPower and Sensitivity are in dBm, they default to 20 and -85
+	TxPower      *float64 `json:"tx_power"`
+	Sensitivity  float64  `json:"sensitivity"`
+	Group        string `json:"group"`
 }
 
 func (r DeviceRequest) ToDomain() entities.Device {
+	txPower := entities.DefaultTxPower
+	if r.TxPower != nil {
+		txPower = *r.TxPower
+	}
+
 	return entities.Device{
 		Label:        r.Label,
 		Power:        r.Power,
+		TxPower:      txPower,
+		Sensitivity:  r.Sensitivity,
+		Group:        r.Group,
 	}
 }
 
@@ -20,14 +36,42 @@ func ToDeviceResponse(d entities.Device) DeviceResponse {
 	routingTable := make([]RoutingResponse, 0)
 
 	routingTable = ToRoutingTableResponse(d.GetRoutingTable()).RoutingTable
+	txPower, sensitivity := d.GetRadio()
+
+	connections := make([]ConnectionResponse, 0)
+	for neighbour, connection := range d.GetDevicesWithConn() {
+		connections = append(connections, ConnectionResponse{
+			Neighbour: neighbour,
+			Forward:   ToLinkQualityResponse(connection.Forward),
+			Reverse:   ToLinkQualityResponse(connection.Reverse),
+		})
+	}
+	slices.SortFunc(connections, func(a, b ConnectionResponse) int {
+		return cmp.Compare(a.Neighbour, b.Neighbour)
+	})
+
+	oneWayLinks := make([]OneWayLinkResponse, 0)
+	for _, link := range d.GetOneWayLinks() {
+		oneWayLinks = append(oneWayLinks, OneWayLinkResponse{
+			Neighbour: link.Neighbour,
+			Direction: link.Direction,
+			Quality:   ToLinkQualityResponse(link.Quality),
+			Since:     link.Since,
+		})
+	}
 
 	return DeviceResponse{
 		Label:        d.Label,
 		Power:        d.Power,
+		TxPower:      txPower,
+		Sensitivity:  sensitivity,
+		Group:        d.Group,
 		Battery:      100,
 		Status:       "active",
 		Requests:     ToRequestsResponse(d.Requests),
 		RoutingTable: routingTable,
+		Connections:  connections,
+		OneWayLinks:  oneWayLinks,
 	}
 }
 
@@ -44,8 +88,40 @@ func ToDevicesResponse(d entities.Devices) []DeviceResponse {
 type DeviceResponse struct {
 	Label        string            `json:"label"`
 	Power        int               `json:"power"`
+	TxPower      float64           `json:"tx_power"`
+	Sensitivity  float64           `json:"sensitivity"`
+	Group        string            `json:"group"`
 	Battery      int               `json:"battery"`
 	Status       string            `json:"status"`
 	Requests     RequestsResponse  `json:"requests"`
 	RoutingTable []RoutingResponse `json:"routing_table"`
+	// Connections are the neighbours the handshake confirmed both ways,
+	// OneWayLinks the ones only one side can use
+	Connections []ConnectionResponse `json:"connections"`
+	OneWayLinks []OneWayLinkResponse `json:"one_way_links"`
+}
+
+type LinkQualityResponse struct {
+	RSSI      float64 `json:"rssi"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+func ToLinkQualityResponse(quality entities.LinkQuality) LinkQualityResponse {
+	return LinkQualityResponse{
+		RSSI:      quality.RSSI,
+		ErrorRate: quality.ErrorRate,
+	}
+}
+
+type ConnectionResponse struct {
+	Neighbour string              `json:"neighbour"`
+	Forward   LinkQualityResponse `json:"forward"`
+	Reverse   LinkQualityResponse `json:"reverse"`
+}
+
+type OneWayLinkResponse struct {
+	Neighbour string              `json:"neighbour"`
+	Direction string              `json:"direction"`
+	Quality   LinkQualityResponse `json:"quality"`
+	Since     time.Time           `json:"since"`
 }
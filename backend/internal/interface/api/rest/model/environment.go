@@ -26,3 +26,35 @@ type EnvironmentResponse struct {
 	Devices []DeviceResponse `json:"devices"`
 	Chart   ChartResponse    `json:"chart"`
 }
+
+type MetricsResponse struct {
+	Metrics []MetricResponse `json:"metrics"`
+}
+
+type MetricResponse struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Combine      string   `json:"combine"`
+	ContentTypes []string `json:"content_types"`
+}
+
+func ToMetricsResponse(metrics []entities.Metric) MetricsResponse {
+	response := make([]MetricResponse, 0, len(metrics))
+	for _, metric := range metrics {
+		contentTypes := metric.ContentTypes
+		if contentTypes == nil {
+			contentTypes = make([]string, 0)
+		}
+
+		response = append(response, MetricResponse{
+			Name:         metric.Name,
+			Description:  metric.Description,
+			Combine:      string(metric.Combine),
+			ContentTypes: contentTypes,
+		})
+	}
+
+	return MetricsResponse{
+		Metrics: response,
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+import "github.com/luuisavelino/network-interface/internal/domain/entities"
+
+type ForwardingRequest struct {
+	Mode string `json:"mode" binding:"required"`
+}
+
+type ForwardingResponse struct {
+	Mode  string                             `json:"mode"`
+	Stats map[string]ForwardingStatsResponse `json:"stats"`
+}
+
+type ForwardingStatsResponse struct {
+	Sent      uint64 `json:"sent"`
+	Delivered uint64 `json:"delivered"`
+	NoRoute   uint64 `json:"no_route"`
+	Expired   uint64 `json:"expired"`
+	Loops     uint64 `json:"loops"`
+}
+
+func ToForwardingResponse(mode string, stats map[string]entities.ForwardingStats) ForwardingResponse {
+	statsResponse := make(map[string]ForwardingStatsResponse, len(stats))
+	for forwarding, modeStats := range stats {
+		statsResponse[forwarding] = ForwardingStatsResponse{
+			Sent:      modeStats.Sent,
+			Delivered: modeStats.Delivered,
+			NoRoute:   modeStats.NoRoute,
+			Expired:   modeStats.Expired,
+			Loops:     modeStats.Loops,
+		}
+	}
+
+	return ForwardingResponse{
+		Mode:  mode,
+		Stats: statsResponse,
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
+
+// MACRequest replaces the MAC config, the times are in microseconds and the
+// fields left out keep their defaults
+type MACRequest struct {
+	Enabled      bool  `json:"enabled"`
+	Slot         int64 `json:"slot"`
+	SIFS         int64 `json:"sifs"`
+	DIFS         int64 `json:"difs"`
+	CWMin        int   `json:"cw_min"`
+	CWMax        int   `json:"cw_max"`
+	RetryLimit   *int  `json:"retry_limit"`
+	RTSThreshold *int  `json:"rts_threshold"`
+}
+
+func (r MACRequest) ToDomain() entities.MACConfig {
+	config := entities.DefaultMACConfig()
+	config.Enabled = r.Enabled
+	if r.Slot != 0 {
+		config.Slot = time.Duration(r.Slot) * time.Microsecond
+	}
+	if r.SIFS != 0 {
+		config.SIFS = time.Duration(r.SIFS) * time.Microsecond
+	}
+	if r.DIFS != 0 {
+		config.DIFS = time.Duration(r.DIFS) * time.Microsecond
+	}
+	if r.CWMin != 0 {
+		config.CWMin = r.CWMin
+	}
+	if r.CWMax != 0 {
+		config.CWMax = r.CWMax
+	}
+	if r.RetryLimit != nil {
+		config.RetryLimit = *r.RetryLimit
+	}
+	if r.RTSThreshold != nil {
+		config.RTSThreshold = *r.RTSThreshold
+	}
+	return config
+}
+
+type MACResponse struct {
+	Enabled      bool                        `json:"enabled"`
+	Slot         int64                       `json:"slot"`
+	SIFS         int64                       `json:"sifs"`
+	DIFS         int64                       `json:"difs"`
+	CWMin        int                         `json:"cw_min"`
+	CWMax        int                         `json:"cw_max"`
+	RetryLimit   int                         `json:"retry_limit"`
+	RTSThreshold int                         `json:"rts_threshold"`
+	Stats        map[string]MACStatsResponse `json:"stats"`
+}
+
+type MACStatsResponse struct {
+	Frames       uint64 `json:"frames"`
+	Delivered    uint64 `json:"delivered"`
+	Dropped      uint64 `json:"dropped"`
+	OutOfRange   uint64 `json:"out_of_range"`
+	WeakSignal   uint64 `json:"weak_signal"`
+	Retries      uint64 `json:"retries"`
+	Collisions   uint64 `json:"collisions"`
+	RTSFailures  uint64 `json:"rts_failures"`
+	ACKTimeouts  uint64 `json:"ack_timeouts"`
+	Deferrals    uint64 `json:"deferrals"`
+	BackoffSlots uint64 `json:"backoff_slots"`
+	// CollisionRate is the part of the attempts lost to concurrent
+	// transmissions
+	CollisionRate float64 `json:"collision_rate"`
+}
+
+func ToMACResponse(config entities.MACConfig, stats map[string]entities.MACStats) MACResponse {
+	statsResponse := make(map[string]MACStatsResponse, len(stats))
+	for label, deviceStats := range stats {
+		var collisionRate float64
+		if attempts := deviceStats.Frames + deviceStats.Retries; attempts > 0 {
+			collisionRate = float64(deviceStats.Collisions) / float64(attempts)
+		}
+
+		statsResponse[label] = MACStatsResponse{
+			Frames:        deviceStats.Frames,
+			Delivered:     deviceStats.Delivered,
+			Dropped:       deviceStats.Dropped,
+			OutOfRange:    deviceStats.OutOfRange,
+			WeakSignal:    deviceStats.WeakSignal,
+			Retries:       deviceStats.Retries,
+			Collisions:    deviceStats.Collisions,
+			RTSFailures:   deviceStats.RTSFailures,
+			ACKTimeouts:   deviceStats.ACKTimeouts,
+			Deferrals:     deviceStats.Deferrals,
+			BackoffSlots:  deviceStats.BackoffSlots,
+			CollisionRate: collisionRate,
+		}
+	}
+
+	return MACResponse{
+		Enabled:      config.Enabled,
+		Slot:         config.Slot.Microseconds(),
+		SIFS:         config.SIFS.Microseconds(),
+		DIFS:         config.DIFS.Microseconds(),
+		CWMin:        config.CWMin,
+		CWMax:        config.CWMax,
+		RetryLimit:   config.RetryLimit,
+		RTSThreshold: config.RTSThreshold,
+		Stats:        statsResponse,
+	}
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
+
+// MediumRequest replaces the shared medium config, the data rate defaults to
+// 1 Mbps, the noise floor to -95 dBm and the preamble to 192 µs
+type MediumRequest struct {
+	Enabled    bool    `json:"enabled"`
+	DataRate   float64 `json:"data_rate"`
+	NoiseFloor float64 `json:"noise_floor"`
+	// Preamble is in microseconds
+	Preamble int64 `json:"preamble"`
+}
+
+func (r MediumRequest) ToDomain() entities.MediumConfig {
+	config := entities.DefaultMediumConfig()
+	config.Enabled = r.Enabled
+	if r.DataRate != 0 {
+		config.DataRate = r.DataRate
+	}
+	if r.NoiseFloor != 0 {
+		config.NoiseFloor = r.NoiseFloor
+	}
+	if r.Preamble != 0 {
+		config.Preamble = time.Duration(r.Preamble) * time.Microsecond
+	}
+	return config
+}
+
+type MediumResponse struct {
+	Enabled    bool                           `json:"enabled"`
+	DataRate   float64                        `json:"data_rate"`
+	NoiseFloor float64                        `json:"noise_floor"`
+	Preamble   int64                          `json:"preamble"`
+	DataRates  []DataRateResponse             `json:"data_rates"`
+	Stats      map[string]MediumStatsResponse `json:"stats"`
+	Collisions []ReceptionResponse            `json:"collisions"`
+}
+
+type DataRateResponse struct {
+	Mbps          float64 `json:"mbps"`
+	SINRThreshold float64 `json:"sinr_threshold"`
+}
+
+type MediumStatsResponse struct {
+	Sent             uint64 `json:"sent"`
+	Received         uint64 `json:"received"`
+	OutOfRange       uint64 `json:"out_of_range"`
+	WeakSignal       uint64 `json:"weak_signal"`
+	Collisions       uint64 `json:"collisions"`
+	HalfDuplex       uint64 `json:"half_duplex"`
+	HiddenCollisions uint64 `json:"hidden_collisions"`
+	// Airtime is in microseconds
+	Airtime int64 `json:"airtime"`
+}
+
+// ReceptionResponse is a frame lost to other frames, the power is in dBm and
+// the SINR in dB. The interference is null when no other frame reached the
+// target.
+type ReceptionResponse struct {
+	Source       string    `json:"source"`
+	Target       string    `json:"target"`
+	Topic        string    `json:"topic"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Status       string    `json:"status"`
+	Signal       float64   `json:"signal"`
+	Interference *float64  `json:"interference"`
+	SINR         float64   `json:"sinr"`
+	Interferers  []string  `json:"interferers"`
+	Hidden       []string  `json:"hidden"`
+}
+
+func ToMediumResponse(config entities.MediumConfig, stats map[string]entities.MediumStats, collisions []entities.Reception) MediumResponse {
+	dataRates := make([]DataRateResponse, 0, len(entities.DataRates))
+	for _, rate := range entities.DataRates {
+		dataRates = append(dataRates, DataRateResponse{
+			Mbps:          rate.Mbps,
+			SINRThreshold: rate.SINRThreshold,
+		})
+	}
+
+	statsResponse := make(map[string]MediumStatsResponse, len(stats))
+	for label, deviceStats := range stats {
+		statsResponse[label] = MediumStatsResponse{
+			Sent:             deviceStats.Sent,
+			Received:         deviceStats.Received,
+			OutOfRange:       deviceStats.OutOfRange,
+			WeakSignal:       deviceStats.WeakSignal,
+			Collisions:       deviceStats.Collisions,
+			HalfDuplex:       deviceStats.HalfDuplex,
+			HiddenCollisions: deviceStats.HiddenCollisions,
+			Airtime:          deviceStats.Airtime.Microseconds(),
+		}
+	}
+
+	collisionsResponse := make([]ReceptionResponse, 0, len(collisions))
+	for _, reception := range collisions {
+		var interference *float64
+		if len(reception.Interferers) > 0 {
+			interference = &reception.Interference
+		}
+
+		collisionsResponse = append(collisionsResponse, ReceptionResponse{
+			Source:       reception.Transmission.Source,
+			Target:       reception.Transmission.Target,
+			Topic:        reception.Transmission.Topic,
+			Start:        reception.Transmission.Start,
+			End:          reception.Transmission.End,
+			Status:       reception.Status,
+			Signal:       reception.Signal,
+			Interference: interference,
+			SINR:         reception.SINR,
+			Interferers:  reception.Interferers,
+			Hidden:       reception.Hidden,
+		})
+	}
+
+	return MediumResponse{
+		Enabled:    config.Enabled,
+		DataRate:   config.DataRate,
+		NoiseFloor: config.NoiseFloor,
+		Preamble:   config.Preamble.Microseconds(),
+		DataRates:  dataRates,
+		Stats:      statsResponse,
+		Collisions: collisionsResponse,
+	}
+}
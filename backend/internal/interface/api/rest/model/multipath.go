@@ -0,0 +1,71 @@
+package model
+
+import "github.com/luuisavelino/network-interface/internal/domain/entities"
+
+// MultipathConfigRequest replaces the multipath config, the variance defaults
+// to 2
+type MultipathConfigRequest struct {
+	Mode      string  `json:"mode" binding:"required"`
+	Balancing string  `json:"balancing" binding:"required"`
+	MaxPaths  int     `json:"max_paths" binding:"required"`
+	Variance  float64 `json:"variance"`
+}
+
+func (r MultipathConfigRequest) ToDomain() entities.MultipathConfig {
+	variance := r.Variance
+	if variance == 0 {
+		variance = 2
+	}
+
+	return entities.MultipathConfig{
+		Mode:      r.Mode,
+		Balancing: r.Balancing,
+		MaxPaths:  r.MaxPaths,
+		Variance:  variance,
+	}
+}
+
+type MultipathResponse struct {
+	Mode      string             `json:"mode"`
+	Balancing string             `json:"balancing"`
+	MaxPaths  int                `json:"max_paths"`
+	Variance  float64            `json:"variance"`
+	Load      []PathLoadResponse `json:"load"`
+}
+
+type PathLoadResponse struct {
+	Source   string   `json:"source"`
+	Target   string   `json:"target"`
+	Path     []string `json:"path"`
+	Messages uint64   `json:"messages"`
+	// Share is the part of the messages from source to target sent over the
+	// path
+	Share float64 `json:"share"`
+}
+
+func ToMultipathResponse(config entities.MultipathConfig, load []entities.PathLoad) MultipathResponse {
+	totals := make(map[entities.Route]uint64)
+	for _, pathLoad := range load {
+		totals[entities.Route{Source: pathLoad.Source, Target: pathLoad.Target}] += pathLoad.Messages
+	}
+
+	loadResponse := make([]PathLoadResponse, 0, len(load))
+	for _, pathLoad := range load {
+		total := totals[entities.Route{Source: pathLoad.Source, Target: pathLoad.Target}]
+		loadResponse = append(loadResponse, PathLoadResponse{
+			Source:   pathLoad.Source,
+			Target:   pathLoad.Target,
+			Path:     pathLoad.Path,
+			Messages: pathLoad.Messages,
+			Share:    float64(pathLoad.Messages) / float64(total),
+		})
+	}
+
+	return MultipathResponse{
+		Mode:      config.Mode,
+		Balancing: config.Balancing,
+		MaxPaths:  config.MaxPaths,
+		Variance:  config.Variance,
+		Load:      loadResponse,
+	}
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
+
+// RoutingPolicyRequest creates or replaces a routing policy, empty match
+// fields match anything
+type RoutingPolicyRequest struct {
+	ContentType      string `json:"content_type"`
+	SenderGroup      string `json:"sender_group"`
+	DestinationGroup string `json:"destination_group"`
+	Metric           string `json:"metric" binding:"required"`
+	FallbackMetric   string `json:"fallback_metric"`
+	Reliability      string `json:"reliability"`
+	Priority         string `json:"priority"`
+}
+
+func (r RoutingPolicyRequest) ToDomain(id uuid.UUID) entities.RoutingPolicy {
+	return entities.RoutingPolicy{
+		ID:               id,
+		ContentType:      r.ContentType,
+		SenderGroup:      r.SenderGroup,
+		DestinationGroup: r.DestinationGroup,
+		Metric:           r.Metric,
+		FallbackMetric:   r.FallbackMetric,
+		Reliability:      r.Reliability,
+		Priority:         r.Priority,
+	}
+}
+
+type RoutingPolicyResponse struct {
+	ID               string `json:"id"`
+	ContentType      string `json:"content_type"`
+	SenderGroup      string `json:"sender_group"`
+	DestinationGroup string `json:"destination_group"`
+	Metric           string `json:"metric"`
+	FallbackMetric   string `json:"fallback_metric"`
+	Reliability      string `json:"reliability"`
+	Priority         string `json:"priority"`
+}
+
+func ToRoutingPolicyResponse(policy entities.RoutingPolicy) RoutingPolicyResponse {
+	return RoutingPolicyResponse{
+		ID:               policy.ID.String(),
+		ContentType:      policy.ContentType,
+		SenderGroup:      policy.SenderGroup,
+		DestinationGroup: policy.DestinationGroup,
+		Metric:           policy.Metric,
+		FallbackMetric:   policy.FallbackMetric,
+		Reliability:      policy.Reliability,
+		Priority:         policy.Priority,
+	}
+}
+
+type RoutingPoliciesResponse struct {
+	Policies []RoutingPolicyResponse `json:"policies"`
+}
+
+func ToRoutingPoliciesResponse(policies []entities.RoutingPolicy) RoutingPoliciesResponse {
+	response := make([]RoutingPolicyResponse, 0, len(policies))
+	for _, policy := range policies {
+		response = append(response, ToRoutingPolicyResponse(policy))
+	}
+
+	return RoutingPoliciesResponse{
+		Policies: response,
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import "github.com/luuisavelino/network-interface/internal/domain/entities"
+
+// PropagationRequest replaces the propagation model, the parameters left out
+// keep their defaults
+type PropagationRequest struct {
+	Model             string  `json:"model" binding:"required"`
+	FrequencyMHz      float64 `json:"frequency_mhz"`
+	PathLossExponent  float64 `json:"path_loss_exponent"`
+	ReferenceDistance float64 `json:"reference_distance"`
+	ShadowingSigma    float64 `json:"shadowing_sigma"`
+	AntennaHeight     float64 `json:"antenna_height"`
+	MetersPerUnit     float64 `json:"meters_per_unit"`
+}
+
+func (r PropagationRequest) ToDomain() entities.PropagationConfig {
+	config := entities.DefaultPropagationConfig()
+	config.Model = r.Model
+	if r.FrequencyMHz != 0 {
+		config.FrequencyMHz = r.FrequencyMHz
+	}
+	if r.PathLossExponent != 0 {
+		config.PathLossExponent = r.PathLossExponent
+	}
+	if r.ReferenceDistance != 0 {
+		config.ReferenceDistance = r.ReferenceDistance
+	}
+	if r.ShadowingSigma != 0 {
+		config.ShadowingSigma = r.ShadowingSigma
+	}
+	if r.AntennaHeight != 0 {
+		config.AntennaHeight = r.AntennaHeight
+	}
+	if r.MetersPerUnit != 0 {
+		config.MetersPerUnit = r.MetersPerUnit
+	}
+	return config
+}
+
+type PropagationResponse struct {
+	Model             string              `json:"model"`
+	FrequencyMHz      float64             `json:"frequency_mhz"`
+	PathLossExponent  float64             `json:"path_loss_exponent"`
+	ReferenceDistance float64             `json:"reference_distance"`
+	ShadowingSigma    float64             `json:"shadowing_sigma"`
+	AntennaHeight     float64             `json:"antenna_height"`
+	MetersPerUnit     float64             `json:"meters_per_unit"`
+	Links             []RadioLinkResponse `json:"links"`
+}
+
+type RadioLinkResponse struct {
+	Source    string  `json:"source"`
+	Target    string  `json:"target"`
+	Distance  float64 `json:"distance"`
+	RSSI      float64 `json:"rssi"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+func ToPropagationResponse(config entities.PropagationConfig, links []entities.RadioLink) PropagationResponse {
+	linksResponse := make([]RadioLinkResponse, 0, len(links))
+	for _, link := range links {
+		linksResponse = append(linksResponse, RadioLinkResponse{
+			Source:    link.Source,
+			Target:    link.Target,
+			Distance:  link.Distance,
+			RSSI:      link.RSSI,
+			ErrorRate: link.ErrorRate,
+		})
+	}
+
+	return PropagationResponse{
+		Model:             config.Model,
+		FrequencyMHz:      config.FrequencyMHz,
+		PathLossExponent:  config.PathLossExponent,
+		ReferenceDistance: config.ReferenceDistance,
+		ShadowingSigma:    config.ShadowingSigma,
+		AntennaHeight:     config.AntennaHeight,
+		MetersPerUnit:     config.MetersPerUnit,
+		Links:             linksResponse,
+	}
+}
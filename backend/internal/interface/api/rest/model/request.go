@@ -35,10 +35,17 @@ type RequestRequest struct {
 }
 
 type Header struct {
-	Topic       string `json:"topic"`
-	Sender      string `json:"sender"`
-	Destination string `json:"destination"`
-	ContentType string `json:"content-type"`
+	Topic       string   `json:"topic"`
+	Sender      string   `json:"sender"`
+	Destination string   `json:"destination"`
+	ContentType string   `json:"content-type"`
+	Members     []string `json:"members,omitempty"`
+	Reliability string   `json:"reliability,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	// Forwarding is source or hop-by-hop, the environment mode when empty
+	Forwarding string   `json:"forwarding,omitempty"`
+	Target     string   `json:"target,omitempty"`
+	Trace      []string `json:"trace,omitempty"`
 }
 
 func (m RequestRequest) ToDomain() entities.Request {
@@ -47,6 +54,8 @@ func (m RequestRequest) ToDomain() entities.Request {
 			Sender:      m.Header.Sender,
 			Destination: m.Header.Destination,
 			ContentType: m.Header.ContentType,
+			Members:     m.Header.Members,
+			Forwarding:  m.Header.Forwarding,
 		},
 		Body: m.Body,
 	}
@@ -63,7 +72,7 @@ type RequestResponse struct {
 func ToRequestResponse(m entities.Request) RequestResponse {
 	var content string
 	switch m.Header.Topic {
-	case "user-message":
+	case "user-message", "broadcast-message":
 		content = m.Body.(string)
 	default:
 		content = m.Header.Topic
@@ -76,6 +85,12 @@ func ToRequestResponse(m entities.Request) RequestResponse {
 			Sender:      m.Header.Sender,
 			Destination: m.Header.Destination,
 			ContentType: m.Header.ContentType,
+			Members:     m.Header.Members,
+			Reliability: m.Header.Reliability,
+			Priority:    m.Header.Priority,
+			Forwarding:  m.Header.Forwarding,
+			Target:      m.Header.Target,
+			Trace:       m.Header.Trace,
 		},
 		Body: content,
 		Read: m.IsRead(),
@@ -1,6 +1,11 @@
 package model
 
-import "github.com/luuisavelino/network-interface/internal/domain/entities"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
 
 type RouteResponse struct {
 	Source string `json:"source"`
@@ -17,3 +22,92 @@ func ToRouteResponse(routes []entities.Route) []RouteResponse {
 	}
 	return routesResponse
 }
+
+// RouteQuery holds the query parameters of the route endpoint. Lists are comma
+// separated and links are written as source:target.
+type RouteQuery struct {
+	Type       string  `form:"type"`
+	Avoid      string  `form:"avoid"`
+	AvoidLinks string  `form:"avoid_links"`
+	Via        string  `form:"via"`
+	MaxHops    int     `form:"max_hops" binding:"min=0"`
+	MaxCost    float64 `form:"max_cost" binding:"min=0"`
+}
+
+// Validate checks what the binding tags can not, every avoided link has to be
+// written as source:target
+func (q RouteQuery) Validate() error {
+	for _, link := range splitList(q.AvoidLinks) {
+		source, target, found := strings.Cut(link, ":")
+		if !found || strings.TrimSpace(source) == "" || strings.TrimSpace(target) == "" {
+			return fmt.Errorf("avoid_links entry %q is not source:target", link)
+		}
+	}
+	return nil
+}
+
+func (q RouteQuery) ToDomain() entities.RouteConstraints {
+	avoidLinks := make([]entities.Route, 0)
+	for _, link := range splitList(q.AvoidLinks) {
+		source, target, _ := strings.Cut(link, ":")
+		avoidLinks = append(avoidLinks, entities.Route{Source: strings.TrimSpace(source), Target: strings.TrimSpace(target)})
+	}
+
+	return entities.RouteConstraints{
+		Avoid:      splitList(q.Avoid),
+		AvoidLinks: avoidLinks,
+		Via:        splitList(q.Via),
+		MaxHops:    q.MaxHops,
+		MaxCost:    q.MaxCost,
+	}
+}
+
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type RouteCacheResponse struct {
+	Device  string                `json:"device"`
+	Hits    uint64                `json:"hits"`
+	Misses  uint64                `json:"misses"`
+	HitRate float64               `json:"hit_rate"`
+	Entries []CachedRouteResponse `json:"entries"`
+}
+
+type CachedRouteResponse struct {
+	Target       string          `json:"target"`
+	Type         string          `json:"type"`
+	Routes       []RouteResponse `json:"routes"`
+	TableVersion uint64          `json:"table_version"`
+}
+
+func ToRouteCacheResponse(device string, stats entities.RouteCacheStats) RouteCacheResponse {
+	entries := make([]CachedRouteResponse, 0, len(stats.Entries))
+	for _, cached := range stats.Entries {
+		entries = append(entries, CachedRouteResponse{
+			Target:       cached.Target,
+			Type:         cached.Type,
+			Routes:       ToRouteResponse(cached.Routes),
+			TableVersion: cached.TableVersion,
+		})
+	}
+
+	var hitRate float64
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		hitRate = float64(stats.Hits) / float64(lookups)
+	}
+
+	return RouteCacheResponse{
+		Device:  device,
+		Hits:    stats.Hits,
+		Misses:  stats.Misses,
+		HitRate: hitRate,
+		Entries: entries,
+	}
+}
@@ -1,6 +1,10 @@
 package model
 
-import "github.com/luuisavelino/network-interface/internal/domain/entities"
+import (
+	"time"
+
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
 
 // import "github.com/luuisavelino/network-interface/internal/domain/entities"
 
@@ -9,10 +13,15 @@ type RoutingTableResponse struct {
 }
 
 type RoutingResponse struct {
-	Source string  `json:"source"`
-	Target string  `json:"target"`
-	Weight float64 `json:"weight"`
-	Type   string  `json:"type"`
+	Source      string    `json:"source"`
+	Target      string    `json:"target"`
+	Weight      float64   `json:"weight"`
+	Type        string    `json:"type"`
+	LearnedFrom string    `json:"learned_from"`
+	LearnedAt   time.Time `json:"learned_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	HopCount    int       `json:"hop_count"`
+	NextHop     string    `json:"next_hop"`
 }
 
 func ToRoutingTableResponse(routingTable entities.Routing) RoutingTableResponse {
@@ -20,13 +29,8 @@ func ToRoutingTableResponse(routingTable entities.Routing) RoutingTableResponse
 
 	for routingType, sources := range routingTable {
 		for source, targets := range sources {
-			for target, weight := range targets {
-				routing = append(routing, RoutingResponse{
-					Source: source,
-					Target: target,
-					Weight: weight,
-					Type:   routingType,
-				})
+			for target, entry := range targets {
+				routing = append(routing, toRoutingResponse(routingType, source, target, entry))
 			}
 		}
 	}
@@ -35,3 +39,220 @@ func ToRoutingTableResponse(routingTable entities.Routing) RoutingTableResponse
 		RoutingTable: routing,
 	}
 }
+
+func toRoutingResponse(routingType, source, target string, entry entities.RouteEntry) RoutingResponse {
+	return RoutingResponse{
+		Source:      source,
+		Target:      target,
+		Weight:      entry.Weight,
+		Type:        routingType,
+		LearnedFrom: entry.LearnedFrom,
+		LearnedAt:   entry.LearnedAt,
+		ExpiresAt:   entry.ExpiresAt,
+		HopCount:    entry.HopCount,
+		NextHop:     entry.NextHop,
+	}
+}
+
+// RoutingTableQuery holds the query parameters of the network wide routing
+// table, empty parameters match everything
+type RoutingTableQuery struct {
+	Device string `form:"device"`
+	Type   string `form:"type"`
+	Source string `form:"source"`
+	Target string `form:"target"`
+	Issues bool   `form:"issues"`
+}
+
+func (q RoutingTableQuery) ToDomain() entities.RoutingTableFilter {
+	return entities.RoutingTableFilter{
+		Device: q.Device,
+		Type:   q.Type,
+		Source: q.Source,
+		Target: q.Target,
+		Issues: q.Issues,
+	}
+}
+
+type RoutingTableViewResponse struct {
+	Devices []string                `json:"devices"`
+	Links   []LinkKnowledgeResponse `json:"links"`
+}
+
+type LinkKnowledgeResponse struct {
+	Type       string             `json:"type"`
+	Source     string             `json:"source"`
+	Target     string             `json:"target"`
+	Weights    map[string]float64 `json:"weights"`
+	Missing    []string           `json:"missing"`
+	Consistent bool               `json:"consistent"`
+	Live       bool               `json:"live"`
+}
+
+func ToRoutingTableViewResponse(view entities.RoutingTableView) RoutingTableViewResponse {
+	links := make([]LinkKnowledgeResponse, 0, len(view.Links))
+	for _, link := range view.Links {
+		links = append(links, LinkKnowledgeResponse{
+			Type:       link.Type,
+			Source:     link.Source,
+			Target:     link.Target,
+			Weights:    link.Weights,
+			Missing:    link.Missing,
+			Consistent: link.Consistent,
+			Live:       link.Live,
+		})
+	}
+
+	return RoutingTableViewResponse{
+		Devices: view.Devices,
+		Links:   links,
+	}
+}
+
+type ConvergenceResponse struct {
+	Converged bool                       `json:"converged"`
+	ChangedAt time.Time                  `json:"changed_at"`
+	ElapsedMs int64                      `json:"elapsed_ms"`
+	Devices   []DeviceStalenessResponse  `json:"devices"`
+	Events    []ConvergenceEventResponse `json:"events"`
+}
+
+type DeviceStalenessResponse struct {
+	Label   string  `json:"label"`
+	Missing int     `json:"missing"`
+	Stale   int     `json:"stale"`
+	Score   float64 `json:"score"`
+}
+
+type ConvergenceEventResponse struct {
+	ChangedAt   time.Time `json:"changed_at"`
+	ConvergedAt time.Time `json:"converged_at"`
+	ElapsedMs   int64     `json:"elapsed_ms"`
+}
+
+func ToConvergenceResponse(status entities.ConvergenceStatus) ConvergenceResponse {
+	devices := make([]DeviceStalenessResponse, 0, len(status.Devices))
+	for _, device := range status.Devices {
+		devices = append(devices, DeviceStalenessResponse{
+			Label:   device.Label,
+			Missing: device.Missing,
+			Stale:   device.Stale,
+			Score:   device.Score,
+		})
+	}
+
+	events := make([]ConvergenceEventResponse, 0, len(status.Events))
+	for _, event := range status.Events {
+		events = append(events, ConvergenceEventResponse{
+			ChangedAt:   event.ChangedAt,
+			ConvergedAt: event.ConvergedAt,
+			ElapsedMs:   event.Elapsed.Milliseconds(),
+		})
+	}
+
+	return ConvergenceResponse{
+		Converged: status.Converged,
+		ChangedAt: status.ChangedAt,
+		ElapsedMs: status.Elapsed.Milliseconds(),
+		Devices:   devices,
+		Events:    events,
+	}
+}
+
+// DeviceRoutingTableQuery selects the version of the routing table of a device
+// current at a point in time, the latest one when at is not given
+type DeviceRoutingTableQuery struct {
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type RoutingVersionResponse struct {
+	Device       string            `json:"device"`
+	Version      uint64            `json:"version"`
+	At           time.Time         `json:"at"`
+	RoutingTable []RoutingResponse `json:"routing_table"`
+}
+
+func ToRoutingVersionResponse(device string, routingVersion entities.RoutingVersion) RoutingVersionResponse {
+	return RoutingVersionResponse{
+		Device:       device,
+		Version:      routingVersion.Version,
+		At:           routingVersion.At,
+		RoutingTable: ToRoutingTableResponse(routingVersion.Table).RoutingTable,
+	}
+}
+
+type RoutingHistoryResponse struct {
+	Device   string                  `json:"device"`
+	Versions []RoutingVersionSummary `json:"versions"`
+}
+
+type RoutingVersionSummary struct {
+	Version uint64    `json:"version"`
+	At      time.Time `json:"at"`
+	Entries int       `json:"entries"`
+}
+
+func ToRoutingHistoryResponse(device string, versions []entities.RoutingVersion) RoutingHistoryResponse {
+	summaries := make([]RoutingVersionSummary, 0, len(versions))
+	for _, routingVersion := range versions {
+		summaries = append(summaries, RoutingVersionSummary{
+			Version: routingVersion.Version,
+			At:      routingVersion.At,
+			Entries: routingVersion.Table.Len(),
+		})
+	}
+
+	return RoutingHistoryResponse{
+		Device:   device,
+		Versions: summaries,
+	}
+}
+
+// RoutingDiffQuery selects the versions of the routing table to compare, to
+// defaults to the latest version and from to the one before to
+type RoutingDiffQuery struct {
+	From uint64 `form:"from"`
+	To   uint64 `form:"to"`
+}
+
+type RoutingDiffResponse struct {
+	Device  string                `json:"device"`
+	From    uint64                `json:"from"`
+	To      uint64                `json:"to"`
+	Added   []RouteChangeResponse `json:"added"`
+	Removed []RouteChangeResponse `json:"removed"`
+	Changed []RouteChangeResponse `json:"changed"`
+}
+
+type RouteChangeResponse struct {
+	Before *RoutingResponse `json:"before"`
+	After  *RoutingResponse `json:"after"`
+}
+
+func ToRoutingDiffResponse(device string, diff entities.RoutingDiff) RoutingDiffResponse {
+	return RoutingDiffResponse{
+		Device:  device,
+		From:    diff.From,
+		To:      diff.To,
+		Added:   toRouteChangesResponse(diff.Added),
+		Removed: toRouteChangesResponse(diff.Removed),
+		Changed: toRouteChangesResponse(diff.Changed),
+	}
+}
+
+func toRouteChangesResponse(changes []entities.RouteChange) []RouteChangeResponse {
+	response := make([]RouteChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		var changeResponse RouteChangeResponse
+		if change.Before != nil {
+			before := toRoutingResponse(change.Type, change.Source, change.Target, *change.Before)
+			changeResponse.Before = &before
+		}
+		if change.After != nil {
+			after := toRoutingResponse(change.Type, change.Source, change.Target, *change.After)
+			changeResponse.After = &after
+		}
+		response = append(response, changeResponse)
+	}
+	return response
+}
@@ -0,0 +1,204 @@
+package model
+
+import (
+	"github.com/luuisavelino/network-interface/internal/domain/entities"
+)
+
+// ExportContentTypes maps the supported topology export formats to the
+// content type of the response
+var ExportContentTypes = map[string]string{
+	"dot":     "text/vnd.graphviz; charset=utf-8",
+	"graphml": "application/graphml+xml; charset=utf-8",
+	"json":    "application/json; charset=utf-8",
+}
+
+// TopologyVersionHeader is the response header with the version of the shared
+// topology graph an export was written from
+const TopologyVersionHeader = "X-Topology-Version"
+
+type DistanceMatrixResponse struct {
+	Type      string       `json:"type"`
+	Version   uint64       `json:"version"`
+	Devices   []string     `json:"devices"`
+	Distances [][]*float64 `json:"distances"`
+	NextHops  [][]*string  `json:"next_hops"`
+}
+
+func ToDistanceMatrixResponse(routingType string, matrix entities.DistanceMatrix) DistanceMatrixResponse {
+	return DistanceMatrixResponse{
+		Type:      routingType,
+		Version:   matrix.Version,
+		Devices:   matrix.Devices,
+		Distances: matrix.Distances,
+		NextHops:  matrix.NextHops,
+	}
+}
+
+type TopologyAnalysisResponse struct {
+	Type                        string             `json:"type"`
+	Version                     uint64             `json:"version"`
+	Partitioned                 bool               `json:"partitioned"`
+	StronglyConnected           bool               `json:"strongly_connected"`
+	StronglyConnectedComponents [][]string         `json:"strongly_connected_components"`
+	WeaklyConnectedComponents   [][]string         `json:"weakly_connected_components"`
+	ArticulationPoints          []string           `json:"articulation_points"`
+	Bridges                     []RouteResponse    `json:"bridges"`
+	Eccentricity                map[string]float64 `json:"eccentricity"`
+	Radius                      *float64           `json:"radius"`
+	Diameter                    *float64           `json:"diameter"`
+}
+
+func ToTopologyAnalysisResponse(routingType string, analysis entities.TopologyAnalysis) TopologyAnalysisResponse {
+	articulationPoints := make([]string, 0)
+	articulationPoints = append(articulationPoints, analysis.ArticulationPoints...)
+
+	return TopologyAnalysisResponse{
+		Type:                        routingType,
+		Version:                     analysis.Version,
+		Partitioned:                 analysis.Partitioned,
+		StronglyConnected:           analysis.StronglyConnected,
+		StronglyConnectedComponents: analysis.StronglyConnectedComponents,
+		WeaklyConnectedComponents:   analysis.WeaklyConnectedComponents,
+		ArticulationPoints:          articulationPoints,
+		Bridges:                     ToRouteResponse(analysis.Bridges),
+		Eccentricity:                analysis.Eccentricity,
+		Radius:                      analysis.Radius,
+		Diameter:                    analysis.Diameter,
+	}
+}
+
+type DeviceCentralityResponse struct {
+	Rank        int     `json:"rank"`
+	Label       string  `json:"label"`
+	Betweenness float64 `json:"betweenness"`
+	Closeness   float64 `json:"closeness"`
+	Degree      float64 `json:"degree"`
+}
+
+type CentralityResponse struct {
+	Type    string                     `json:"type"`
+	Version uint64                     `json:"version"`
+	Devices []DeviceCentralityResponse `json:"devices"`
+}
+
+func ToCentralityResponse(routingType string, version uint64, ranking []entities.DeviceCentrality) CentralityResponse {
+	devices := make([]DeviceCentralityResponse, 0, len(ranking))
+	for i, device := range ranking {
+		devices = append(devices, DeviceCentralityResponse{
+			Rank:        i + 1,
+			Label:       device.Label,
+			Betweenness: device.Betweenness,
+			Closeness:   device.Closeness,
+			Degree:      device.Degree,
+		})
+	}
+
+	return CentralityResponse{
+		Type:    routingType,
+		Version: version,
+		Devices: devices,
+	}
+}
+
+// BackboneQuery holds the query parameters of the backbone endpoint, terminals
+// are comma separated
+type BackboneQuery struct {
+	Type      string `form:"type,default=distance"`
+	Algorithm string `form:"algorithm,default=prim"`
+	Terminals string `form:"terminals"`
+}
+
+func (q BackboneQuery) GetTerminals() []string {
+	return splitList(q.Terminals)
+}
+
+type BackboneResponse struct {
+	Type      string                 `json:"type"`
+	Version   uint64                 `json:"version"`
+	Algorithm string                 `json:"algorithm"`
+	Terminals []string               `json:"terminals"`
+	Weight    float64                `json:"weight"`
+	Links     []BackboneLinkResponse `json:"links"`
+}
+
+type BackboneLinkResponse struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func ToBackboneResponse(routingType string, backbone entities.Backbone) BackboneResponse {
+	terminals := make([]string, 0, len(backbone.Terminals))
+	terminals = append(terminals, backbone.Terminals...)
+
+	links := make([]BackboneLinkResponse, 0, len(backbone.Links))
+	for _, link := range backbone.Links {
+		links = append(links, BackboneLinkResponse{
+			Source: link.Source,
+			Target: link.Target,
+		})
+	}
+
+	return BackboneResponse{
+		Type:      routingType,
+		Version:   backbone.Version,
+		Algorithm: backbone.Algorithm,
+		Terminals: terminals,
+		Weight:    backbone.Weight,
+		Links:     links,
+	}
+}
+
+// MaxFlowQuery holds the query parameters of the max flow endpoint, sources and
+// sinks are comma separated
+type MaxFlowQuery struct {
+	Sources string `form:"sources" binding:"required"`
+	Sinks   string `form:"sinks" binding:"required"`
+}
+
+func (q MaxFlowQuery) GetSources() []string {
+	return splitList(q.Sources)
+}
+
+func (q MaxFlowQuery) GetSinks() []string {
+	return splitList(q.Sinks)
+}
+
+type MaxFlowResponse struct {
+	Version uint64             `json:"version"`
+	Sources []string           `json:"sources"`
+	Sinks   []string           `json:"sinks"`
+	Value   float64            `json:"value"`
+	Flows   []LinkFlowResponse `json:"flows"`
+	MinCut  []LinkFlowResponse `json:"min_cut"`
+}
+
+type LinkFlowResponse struct {
+	Source   string  `json:"source"`
+	Target   string  `json:"target"`
+	Flow     float64 `json:"flow"`
+	Capacity float64 `json:"capacity"`
+}
+
+func ToMaxFlowResponse(maxFlow entities.MaxFlow) MaxFlowResponse {
+	return MaxFlowResponse{
+		Version: maxFlow.Version,
+		Sources: maxFlow.Sources,
+		Sinks:   maxFlow.Sinks,
+		Value:   maxFlow.Value,
+		Flows:   toLinkFlowsResponse(maxFlow.Flows),
+		MinCut:  toLinkFlowsResponse(maxFlow.MinCut),
+	}
+}
+
+func toLinkFlowsResponse(flows []entities.LinkFlow) []LinkFlowResponse {
+	response := make([]LinkFlowResponse, 0, len(flows))
+	for _, flow := range flows {
+		response = append(response, LinkFlowResponse{
+			Source:   flow.Source,
+			Target:   flow.Target,
+			Flow:     flow.Flow,
+			Capacity: flow.Capacity,
+		})
+	}
+	return response
+}
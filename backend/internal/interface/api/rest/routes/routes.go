@@ -14,6 +14,10 @@ func InitRoutes(r *gin.RouterGroup, controller controllers.ApiControllerInterfac
 		devices.POST("", controller.InsertDevice)
 		devices.PATCH("/:label", controller.UpdateRoutingTable)
 		devices.GET("/:label", controller.GetDevice)
+		devices.GET("/:label/routing-table", controller.GetDeviceTable)
+		devices.GET("/:label/routing-table/history", controller.GetDeviceTableHistory)
+		devices.GET("/:label/routing-table/diff", controller.GetDeviceTableDiff)
+		devices.GET("/:label/route-cache", controller.GetRouteCache)
 		devices.DELETE("/:label", controller.DeleteDevice)
 		devices.GET("/route/:source/:target", controller.GetRoute)
 		devices.POST("/requests", controller.SendRequest)
@@ -22,11 +26,38 @@ func InitRoutes(r *gin.RouterGroup, controller controllers.ApiControllerInterfac
 	chart := v1.Group("/chart")
 	{
 		chart.GET("", controller.GetChart)
+		chart.GET("/links", controller.GetChartLinks)
 		chart.POST("/:deviceLabel", controller.SetDeviceInChart)
 	}
 
 	environment := v1.Group("/environment")
 	{
 		environment.GET("", controller.GetEnvironment)
+		environment.GET("/metrics", controller.GetMetrics)
+		environment.GET("/policies", controller.GetPolicies)
+		environment.POST("/policies", controller.InsertPolicy)
+		environment.PUT("/policies/:id", controller.UpdatePolicy)
+		environment.DELETE("/policies/:id", controller.DeletePolicy)
+		environment.GET("/multipath", controller.GetMultipath)
+		environment.PUT("/multipath", controller.SetMultipathConfig)
+		environment.DELETE("/multipath/load", controller.ResetMultipathLoad)
+		environment.GET("/forwarding", controller.GetForwarding)
+		environment.PUT("/forwarding", controller.SetForwardingMode)
+		environment.GET("/propagation", controller.GetPropagation)
+		environment.PUT("/propagation", controller.SetPropagation)
+		environment.GET("/medium", controller.GetMedium)
+		environment.PUT("/medium", controller.SetMediumConfig)
+		environment.DELETE("/medium/stats", controller.ResetMediumStats)
+		environment.GET("/mac", controller.GetMAC)
+		environment.PUT("/mac", controller.SetMACConfig)
+		environment.DELETE("/mac/stats", controller.ResetMACStats)
+		environment.GET("/routing-table", controller.GetTable)
+		environment.GET("/convergence", controller.GetConvergence)
+		environment.GET("/distance-matrix", controller.GetDistanceMatrix)
+		environment.GET("/topology/analysis", controller.GetTopologyAnalysis)
+		environment.GET("/topology/centrality", controller.GetCentrality)
+		environment.GET("/topology/export", controller.GetTopologyExport)
+		environment.GET("/topology/backbone", controller.GetBackbone)
+		environment.GET("/topology/max-flow", controller.GetMaxFlow)
 	}
 }
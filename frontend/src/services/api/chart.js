@@ -17,6 +17,16 @@ const getChart = () => {
   return axios.request(config)
 }
 
+const getLinks = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/links',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
 const setDeviceInChart = (device, data) => {
   const config = {
     method: 'post',
@@ -30,5 +40,6 @@ const setDeviceInChart = (device, data) => {
 
 export default {
   getChart,
+  getLinks,
   setDeviceInChart,
 }
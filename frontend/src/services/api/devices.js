@@ -38,10 +38,56 @@ const getDeviceById = (deviceId) => {
   return axios.request(config)
 }
 
-const getRoute = (sourceId, targetId, type = "distance") => {
+const getRoute = (sourceId, targetId, type = "distance", constraints = {}) => {
   const config = {
     method: 'get',
-    url: API_URL + '/route/' + sourceId + '/' + targetId + '?type=' + type,
+    url: API_URL + '/route/' + sourceId + '/' + targetId,
+    headers,
+    params: {
+      type,
+      ...constraints,
+    },
+  };
+
+  return axios.request(config)
+}
+
+const getRoutingTable = (deviceLabel, at) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/' + deviceLabel + '/routing-table',
+    headers,
+    params: { at },
+  };
+
+  return axios.request(config)
+}
+
+const getRoutingHistory = (deviceLabel) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/' + deviceLabel + '/routing-table/history',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getRoutingDiff = (deviceLabel, from, to) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/' + deviceLabel + '/routing-table/diff',
+    headers,
+    params: { from, to },
+  };
+
+  return axios.request(config)
+}
+
+const getRouteCache = (deviceLabel) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/' + deviceLabel + '/route-cache',
     headers,
   };
 
@@ -74,6 +120,10 @@ export default {
   insertDevice,
   getDeviceById,
   getRoute,
+  getRoutingTable,
+  getRoutingHistory,
+  getRoutingDiff,
+  getRouteCache,
   deleteDevice,
   sendRequest,
 }
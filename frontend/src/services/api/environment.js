@@ -17,6 +17,307 @@ const getEnvironment = () => {
   return axios.request(config)
 }
 
+const getMetrics = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/metrics',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getPolicies = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/policies',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const insertPolicy = (data) => {
+  const config = {
+    method: 'post',
+    url: API_URL + '/policies',
+    headers,
+    data,
+  };
+
+  return axios.request(config)
+}
+
+const updatePolicy = (policyId, data) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/policies/' + policyId,
+    headers,
+    data,
+  };
+
+  return axios.request(config)
+}
+
+const deletePolicy = (policyId) => {
+  const config = {
+    method: 'delete',
+    url: API_URL + '/policies/' + policyId,
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getMultipath = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/multipath',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const setMultipathConfig = (data) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/multipath',
+    headers,
+    data,
+  };
+
+  return axios.request(config)
+}
+
+const resetMultipathLoad = () => {
+  const config = {
+    method: 'delete',
+    url: API_URL + '/multipath/load',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getForwarding = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/forwarding',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const setForwardingMode = (mode) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/forwarding',
+    headers,
+    data: { mode },
+  };
+
+  return axios.request(config)
+}
+
+const getPropagation = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/propagation',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const setPropagation = (propagation) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/propagation',
+    headers,
+    data: propagation,
+  };
+
+  return axios.request(config)
+}
+
+const getMedium = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/medium',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const setMediumConfig = (medium) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/medium',
+    headers,
+    data: medium,
+  };
+
+  return axios.request(config)
+}
+
+const resetMediumStats = () => {
+  const config = {
+    method: 'delete',
+    url: API_URL + '/medium/stats',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getMAC = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/mac',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const setMACConfig = (mac) => {
+  const config = {
+    method: 'put',
+    url: API_URL + '/mac',
+    headers,
+    data: mac,
+  };
+
+  return axios.request(config)
+}
+
+const resetMACStats = () => {
+  const config = {
+    method: 'delete',
+    url: API_URL + '/mac/stats',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getRoutingTable = (filter = {}) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/routing-table',
+    headers,
+    params: filter,
+  };
+
+  return axios.request(config)
+}
+
+const getConvergence = () => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/convergence',
+    headers,
+  };
+
+  return axios.request(config)
+}
+
+const getDistanceMatrix = (type = "distance") => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/distance-matrix',
+    headers,
+    params: { type },
+  };
+
+  return axios.request(config)
+}
+
+const getTopologyAnalysis = (type = "distance") => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/topology/analysis',
+    headers,
+    params: { type },
+  };
+
+  return axios.request(config)
+}
+
+const getCentrality = (type = "distance") => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/topology/centrality',
+    headers,
+    params: { type },
+  };
+
+  return axios.request(config)
+}
+
+const exportTopology = (format = "json", type = "distance") => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/topology/export',
+    headers,
+    params: { format, type },
+    responseType: 'text',
+  };
+
+  return axios.request(config)
+}
+
+const getBackbone = (type = "distance", algorithm = "prim", terminals = []) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/topology/backbone',
+    headers,
+    params: { type, algorithm, terminals: terminals.join(',') },
+  };
+
+  return axios.request(config)
+}
+
+const getMaxFlow = (sources = [], sinks = []) => {
+  const config = {
+    method: 'get',
+    url: API_URL + '/topology/max-flow',
+    headers,
+    params: { sources: sources.join(','), sinks: sinks.join(',') },
+  };
+
+  return axios.request(config)
+}
+
 export default {
   getEnvironment,
+  getMetrics,
+  getPolicies,
+  insertPolicy,
+  updatePolicy,
+  deletePolicy,
+  getMultipath,
+  setMultipathConfig,
+  resetMultipathLoad,
+  getForwarding,
+  setForwardingMode,
+  getPropagation,
+  setPropagation,
+  getMedium,
+  setMediumConfig,
+  resetMediumStats,
+  getMAC,
+  setMACConfig,
+  resetMACStats,
+  getRoutingTable,
+  getConvergence,
+  getDistanceMatrix,
+  getTopologyAnalysis,
+  getCentrality,
+  exportTopology,
+  getBackbone,
+  getMaxFlow,
 }